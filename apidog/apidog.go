@@ -0,0 +1,47 @@
+package apidog
+
+import (
+	_ "embed"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// spec is the OpenAPI document, built into the binary so the served copy
+// always matches the code it was released with
+//
+//go:embed job-tracker-api.json
+var spec []byte
+
+// swaggerUIVersion is the swagger-ui-dist release the docs page loads
+const swaggerUIVersion = "5.17.14"
+
+// docsPage renders Swagger UI against /openapi.json. The UI assets come from
+// a CDN, so the page needs internet access but the app needs no extra files.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Job Tracker API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@` + swaggerUIVersion + `/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui", persistAuthorization: true});
+  </script>
+</body>
+</html>
+`
+
+// SpecHandler serves the raw OpenAPI document
+func SpecHandler(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	return c.Send(spec)
+}
+
+// DocsHandler serves the Swagger UI page
+func DocsHandler(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(docsPage)
+}
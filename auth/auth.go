@@ -0,0 +1,83 @@
+// Package auth issues and verifies the JWTs that authenticate API requests.
+// Tokens are HS256-signed with JWT_SECRET and must carry an expiry.
+package auth
+
+import (
+	"errors"
+	"job-tracker/config"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Reasons a token is refused, reported to the client
+var (
+	ErrTokenExpired     = errors.New("token has expired")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrInvalidToken     = errors.New("invalid token")
+)
+
+// Claims are the claims the API reads from a token
+type Claims struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// Signing settings, set once at startup by Configure
+var (
+	secret   []byte
+	tokenTTL = time.Hour
+)
+
+// Configure sets the signing secret and token lifetime
+func Configure(cfg config.AuthConfig) {
+	secret = cfg.JWTSecret
+	if cfg.TokenTTL > 0 {
+		tokenTTL = cfg.TokenTTL
+	}
+}
+
+// Issue signs a token for a user that expires after JWT_TTL
+func Issue(userID, role string) (string, time.Time, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(tokenTTL)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		UserID: userID,
+		Role:   role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+	signed, err := token.SignedString(secret)
+	return signed, expiresAt, err
+}
+
+// parser only accepts HS256, so a token can't pick a weaker algorithm or
+// "none", and rejects tokens without an expiry
+var parser = jwt.NewParser(
+	jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+	jwt.WithExpirationRequired(),
+)
+
+// Parse verifies a token's signature and expiry and returns its claims
+func Parse(token string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return secret, nil
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return nil, ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return nil, ErrInvalidSignature
+	default:
+		return nil, ErrInvalidToken
+	}
+	if claims.UserID == "" || claims.Role == "" {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
@@ -0,0 +1,77 @@
+package authaudit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"job-tracker/database"
+	"job-tracker/jobs"
+	"job-tracker/models"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Event types. Login, refresh, revocation and API key events are recorded by
+// the handlers that issue or check those credentials.
+const (
+	Login         = "login"
+	LoginFailed   = "login_failed"
+	TokenUsed     = "token_used"
+	TokenRejected = "token_rejected"
+	TokenRefresh  = "token_refresh"
+	TokenRevoked  = "token_revoked"
+	APIKeyUsed    = "api_key_used"
+	APIKeyFailed  = "api_key_rejected"
+)
+
+// Types lists every event type, for validating filters
+var Types = []string{Login, LoginFailed, TokenUsed, TokenRejected, TokenRefresh, TokenRevoked, APIKeyUsed, APIKeyFailed}
+
+// queue writes events off the request path. Events are written inline until
+// SetQueue is called.
+var queue jobs.Queue
+
+// SetQueue sets the queue events are written through
+func SetQueue(q jobs.Queue) {
+	queue = q
+}
+
+// Record stores an authentication event for the request. Failing to store it
+// is logged but never fails the request.
+func Record(c *fiber.Ctx, eventType, identity string, success bool, detail string) {
+	event := models.AuthEvent{
+		Type:      eventType,
+		Success:   success,
+		Identity:  truncate(identity, 100),
+		IP:        c.IP(),
+		UserAgent: truncate(c.Get(fiber.HeaderUserAgent), 255),
+		Detail:    detail,
+	}
+
+	write := func(ctx context.Context) error {
+		return database.DB.WithContext(ctx).Create(&event).Error
+	}
+	if queue == nil {
+		if err := write(context.Background()); err != nil {
+			log.Printf("Failed to record %s auth event: %v", eventType, err)
+		}
+		return
+	}
+	if err := queue.Enqueue(jobs.Job{Name: "auth-event:" + eventType, Run: write}); err != nil {
+		log.Printf("Failed to queue %s auth event: %v", eventType, err)
+	}
+}
+
+// Fingerprint identifies a credential in the event log without storing it
+func Fingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
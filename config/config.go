@@ -0,0 +1,837 @@
+package config
+
+import (
+	"encoding/base64"
+	"fmt"
+	"job-tracker/utils"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// Deployment environments. Production refuses settings that are only safe
+// on a developer machine.
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// ServerConfig holds the HTTP server settings
+type ServerConfig struct {
+	Port string
+	// Env is development or production
+	Env string
+}
+
+// Production reports whether the server runs in production mode
+func (s ServerConfig) Production() bool {
+	return s.Env == EnvProduction
+}
+
+// DatabaseConfig holds the PostgreSQL connection and pool settings
+type DatabaseConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+
+	MaxIdleConns    int
+	MaxOpenConns    int
+	ConnMaxLifetime time.Duration
+	// LogLevel is how much GORM logs: silent, error, warn or info (every query)
+	LogLevel logger.LogLevel
+	// Logger replaces the default GORM logger when set, for example to capture
+	// queries in tests; LogLevel is then ignored
+	Logger logger.Interface
+}
+
+// DSN returns the connection string for the PostgreSQL driver
+func (db DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
+		db.Host, db.User, db.Password, db.Name, db.Port)
+}
+
+// RedisConfig holds the Redis connection settings
+type RedisConfig struct {
+	Host     string
+	Port     string
+	Password string
+}
+
+// Addr returns the host:port Redis listens on
+func (r RedisConfig) Addr() string {
+	return net.JoinHostPort(r.Host, r.Port)
+}
+
+// MaxCacheWarmPages caps how many list pages a cache reconcile may pre-load
+const MaxCacheWarmPages = 10
+
+// CacheConfig holds the Redis response cache settings
+type CacheConfig struct {
+	// TTL is how long cached applicant list pages and applicants live
+	TTL time.Duration
+	// ReconcileOnStart flushes the applicant cache at startup and pre-loads
+	// the first WarmPages unfiltered list pages
+	ReconcileOnStart bool
+	WarmPages        int
+}
+
+// Policies for creating an applicant whose email belongs to a soft-deleted one
+const (
+	// EmailReuseReject refuses the new applicant
+	EmailReuseReject = "reject"
+	// EmailReuseRestore brings the deleted applicant back with the new details
+	EmailReuseRestore = "restore"
+	// EmailReuseRelease renames the deleted applicant's email so a new
+	// applicant can be created with it
+	EmailReuseRelease = "release"
+)
+
+// ApplicantsConfig holds the rules applied to applicant records
+type ApplicantsConfig struct {
+	// StatusSLA is the longest an applicant may stay in each status before
+	// it is reported as overdue; statuses without an entry never are
+	StatusSLA map[string]time.Duration
+	// Maximum number of characters accepted for the free-text fields
+	MaxNotesLength   int
+	MaxResumeLength  int
+	MaxCommentLength int
+	// MaxBatchSize caps the number of ids accepted by the batch endpoints
+	MaxBatchSize int
+	// ExportChunkSize is the number of rows each export query reads
+	ExportChunkSize int
+	// CacheMaxAge is how long clients and proxies may cache a single
+	// applicant response; zero disables caching
+	CacheMaxAge time.Duration
+	// ReviewLockTTL is how long GET /applicants/next reserves an applicant
+	// for the reviewer it was handed to
+	ReviewLockTTL time.Duration
+	// UpdatableFields are the fields PUT and PATCH may change; empty allows
+	// every editable field
+	UpdatableFields []string
+	// RequiredFields are required on create on top of name, email and position
+	RequiredFields []string
+	// DefaultStatus is given to applicants created without a status
+	DefaultStatus utils.Status
+	// DefaultCurrency applies to salaries and salary filters without a currency
+	DefaultCurrency string
+	// DefaultPhoneCountryCode, without the "+", is given to phone numbers
+	// entered without one; they are stored as bare digits when it is empty
+	DefaultPhoneCountryCode string
+	// RequirePhoneCountryCode rejects phone numbers without a "+" prefix
+	RequirePhoneCountryCode bool
+	// EmailNormalizeProviders are the mail domains whose addresses ignore
+	// dots and +tags in the local part, such as gmail.com
+	EmailNormalizeProviders []string
+	// EmailReusePolicy is reject, restore or release
+	EmailReusePolicy string
+	// ReopenStatus is the status a terminal applicant is reopened to, by a
+	// user with one of ReopenRoles
+	ReopenStatus utils.Status
+	ReopenRoles  []string
+}
+
+// dbLogLevels maps DB_LOG_LEVEL values to GORM log levels
+var dbLogLevels = map[string]logger.LogLevel{
+	"silent": logger.Silent,
+	"error":  logger.Error,
+	"warn":   logger.Warn,
+	"info":   logger.Info,
+}
+
+// SMTPConfig holds the outgoing mail server settings. Emails are only logged
+// when Host is empty.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// NotificationsConfig holds the applicant email settings
+type NotificationsConfig struct {
+	TemplatesDir string
+	// StatusTemplates maps a status to the template used for it; other
+	// statuses use the default template
+	StatusTemplates map[string]string
+	SMTP            SMTPConfig
+}
+
+// QuotaConfig holds the per-role request quotas for authenticated users. A
+// quota of 0 is unlimited; the "default" entry applies to roles without one.
+type QuotaConfig struct {
+	Daily   map[string]int64
+	Monthly map[string]int64
+	// WarnBelow is the share of a quota left, between 0 and 1, under which
+	// responses warn that it is nearly used up; 0 turns the warning off
+	WarnBelow float64
+}
+
+// RateLimitConfig holds the per-IP request limit
+type RateLimitConfig struct {
+	// Requests is the number allowed per client IP in each Window; 0 turns
+	// the limit off
+	Requests int
+	Window   time.Duration
+	// ExemptPaths are never limited, along with anything below them
+	ExemptPaths []string
+}
+
+// JobsConfig holds the background worker pool settings
+type JobsConfig struct {
+	Workers   int
+	QueueSize int
+	// MaxAttempts is the number of times a failing job is run before it is
+	// logged as a dead letter
+	MaxAttempts int
+	// RetryBackoff is the wait before the first retry; it doubles after each attempt
+	RetryBackoff time.Duration
+	// DrainTimeout bounds how long shutdown waits for queued jobs
+	DrainTimeout time.Duration
+}
+
+// UploadConfig holds the limits applied to file uploads
+type UploadConfig struct {
+	// MaxConcurrent is the number of uploads handled at the same time
+	MaxConcurrent int
+	// MaxBytes is the largest accepted file
+	MaxBytes int64
+	// RetryAfter is suggested to clients turned away because all slots are busy
+	RetryAfter time.Duration
+	// Dir is where uploaded files are stored
+	Dir string
+}
+
+// MultipartOverhead is the room allowed on top of UploadConfig.MaxBytes for
+// the multipart form framing around an uploaded file
+const MultipartOverhead = 64 << 10
+
+// MaxRequestBytes is the largest upload request body, a file of MaxBytes in
+// its multipart framing
+func (u UploadConfig) MaxRequestBytes() int64 {
+	return u.MaxBytes + MultipartOverhead
+}
+
+// WorkflowConfig holds the status change automation settings
+type WorkflowConfig struct {
+	// RulesFile is a JSON list of rules; no rules run when it is empty
+	RulesFile string
+}
+
+// Maintenance modes
+const (
+	MaintenanceOff      = "off"
+	MaintenanceReadOnly = "read_only"
+	MaintenanceOn       = "on"
+)
+
+// MaintenanceConfig controls taking the API down for deploys and migrations
+type MaintenanceConfig struct {
+	// Mode is off, read_only (only GET, HEAD and OPTIONS are served) or on
+	Mode       string
+	Message    string
+	RetryAfter time.Duration
+	// AllowPaths stay up in every mode, along with anything below them
+	AllowPaths []string
+}
+
+// PIIConfig holds the keys used to encrypt personal data at rest
+type PIIConfig struct {
+	// ActiveKeyID names the key new values are encrypted with; encryption is
+	// off when it is empty
+	ActiveKeyID string
+	// Keys are 32-byte AES keys by id. Retired keys stay listed so values
+	// written with them can still be read.
+	Keys map[string][]byte
+}
+
+// AuthConfig holds the settings for authenticating requests
+type AuthConfig struct {
+	// JWTSecret is the HS256 key tokens are signed with
+	JWTSecret []byte
+	// TokenTTL is how long a token issued at login stays valid
+	TokenTTL time.Duration
+	// AdminEmail and AdminPassword create the first admin account at startup
+	// when no user has that email yet
+	AdminEmail    string
+	AdminPassword string
+}
+
+// minJWTSecretLength is the shortest accepted JWT_SECRET, the HS256 key size
+const minJWTSecretLength = 32
+
+// EventsConfig holds the applicant event settings
+type EventsConfig struct {
+	// LogTypes are the event types written to the log; "*" logs every type
+	LogTypes []string
+	// WebhookTimeout bounds each webhook delivery request
+	WebhookTimeout time.Duration
+}
+
+// InfoConfig holds what the root path reports about the API
+type InfoConfig struct {
+	Name string
+	// DocsURL is linked from the root response when set; it defaults to the
+	// built-in Swagger UI
+	DocsURL string
+}
+
+// Config is the application configuration, read from the environment at startup
+type Config struct {
+	Server        ServerConfig
+	Database      DatabaseConfig
+	Redis         RedisConfig
+	Cache         CacheConfig
+	Applicants    ApplicantsConfig
+	Notifications NotificationsConfig
+	Quota         QuotaConfig
+	RateLimit     RateLimitConfig
+	Info          InfoConfig
+	Jobs          JobsConfig
+	Upload        UploadConfig
+	Workflow      WorkflowConfig
+	Maintenance   MaintenanceConfig
+	PII           PIIConfig
+	Events        EventsConfig
+	Auth          AuthConfig
+}
+
+// Load reads the configuration from environment variables, applying defaults
+// and returning an error if any value is invalid
+func Load() (*Config, error) {
+	var cfg Config
+	var err error
+
+	cfg.Server = ServerConfig{
+		Port: getEnv("PORT", "3000"),
+		Env:  strings.ToLower(getEnv("ENVIRONMENT", EnvDevelopment)),
+	}
+
+	cfg.Database.Host = getEnv("DB_HOST", "localhost")
+	cfg.Database.Port = getEnv("DB_PORT", "5432")
+	cfg.Database.User = getEnv("DB_USER", "postgres")
+	cfg.Database.Password = getEnv("DB_PASSWORD", "")
+	cfg.Database.Name = getEnv("DB_NAME", "postgres")
+	if cfg.Database.MaxIdleConns, err = getEnvInt("DB_MAX_IDLE_CONNS", 10); err != nil {
+		return nil, err
+	}
+	if cfg.Database.MaxOpenConns, err = getEnvInt("DB_MAX_OPEN_CONNS", 100); err != nil {
+		return nil, err
+	}
+	if cfg.Database.ConnMaxLifetime, err = getEnvDuration("DB_CONN_MAX_LIFETIME", time.Hour); err != nil {
+		return nil, err
+	}
+	logLevel := strings.ToLower(getEnv("DB_LOG_LEVEL", "warn"))
+	level, ok := dbLogLevels[logLevel]
+	if !ok {
+		return nil, fmt.Errorf("DB_LOG_LEVEL must be silent, error, warn or info, got %q", logLevel)
+	}
+	cfg.Database.LogLevel = level
+
+	cfg.Redis = RedisConfig{
+		Host:     getEnv("REDIS_HOST", "localhost"),
+		Port:     getEnv("REDIS_PORT", "6379"),
+		Password: getEnv("REDIS_PASSWORD", ""),
+	}
+	if cfg.Cache.TTL, err = getEnvDuration("CACHE_TTL", 3*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.Cache.ReconcileOnStart, err = getEnvBool("RECONCILE_CACHE_ON_START", false); err != nil {
+		return nil, err
+	}
+	if cfg.Cache.WarmPages, err = getEnvInt("CACHE_WARM_PAGES", 1); err != nil {
+		return nil, err
+	}
+
+	if err := loadApplicants(&cfg.Applicants); err != nil {
+		return nil, err
+	}
+
+	cfg.Notifications = NotificationsConfig{
+		TemplatesDir: getEnv("EMAIL_TEMPLATES_DIR", "templates/email"),
+		StatusTemplates: map[string]string{
+			"interviewed": "interviewed",
+			"hired":       "hired",
+			"rejected":    "rejected",
+		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "recruiting@example.com"),
+		},
+	}
+	if raw := getEnv("EMAIL_TEMPLATES", ""); raw != "" {
+		if cfg.Notifications.StatusTemplates, err = parseStatusMap("EMAIL_TEMPLATES", raw); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Quota.Daily, err = getEnvQuotas("QUOTA_DAILY", "default=1000,admin=0"); err != nil {
+		return nil, err
+	}
+	if cfg.Quota.Monthly, err = getEnvQuotas("QUOTA_MONTHLY", "default=20000,admin=0"); err != nil {
+		return nil, err
+	}
+	warnBelow := getEnv("QUOTA_WARN_BELOW", "0.1")
+	if cfg.Quota.WarnBelow, err = strconv.ParseFloat(warnBelow, 64); err != nil || cfg.Quota.WarnBelow < 0 || cfg.Quota.WarnBelow >= 1 {
+		return nil, fmt.Errorf("QUOTA_WARN_BELOW must be a fraction from 0 up to 1, got %q", warnBelow)
+	}
+
+	if cfg.RateLimit.Requests, err = getEnvInt("RATE_LIMIT_REQUESTS", 120); err != nil {
+		return nil, err
+	}
+	if cfg.RateLimit.Window, err = getEnvDuration("RATE_LIMIT_WINDOW", time.Minute); err != nil {
+		return nil, err
+	}
+	cfg.RateLimit.ExemptPaths = getEnvList("RATE_LIMIT_EXEMPT_PATHS", "/health")
+
+	if cfg.Jobs.Workers, err = getEnvInt("JOB_WORKERS", 4); err != nil {
+		return nil, err
+	}
+	if cfg.Jobs.QueueSize, err = getEnvInt("JOB_QUEUE_SIZE", 100); err != nil {
+		return nil, err
+	}
+	if cfg.Jobs.MaxAttempts, err = getEnvInt("JOB_MAX_ATTEMPTS", 3); err != nil {
+		return nil, err
+	}
+	if cfg.Jobs.RetryBackoff, err = getEnvDuration("JOB_RETRY_BACKOFF", 2*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.Jobs.DrainTimeout, err = getEnvDuration("JOB_DRAIN_TIMEOUT", 30*time.Second); err != nil {
+		return nil, err
+	}
+
+	if cfg.Upload.MaxConcurrent, err = getEnvInt("UPLOAD_MAX_CONCURRENT", 4); err != nil {
+		return nil, err
+	}
+	maxUploadBytes, err := getEnvInt("UPLOAD_MAX_BYTES", 5<<20)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Upload.MaxBytes = int64(maxUploadBytes)
+	if cfg.Upload.RetryAfter, err = getEnvDuration("UPLOAD_RETRY_AFTER", 5*time.Second); err != nil {
+		return nil, err
+	}
+	cfg.Upload.Dir = getEnv("UPLOAD_DIR", "uploads")
+
+	cfg.Workflow.RulesFile = getEnv("WORKFLOW_RULES_FILE", "")
+
+	cfg.Maintenance = MaintenanceConfig{
+		Mode:       strings.ToLower(getEnv("MAINTENANCE_MODE", MaintenanceOff)),
+		Message:    getEnv("MAINTENANCE_MESSAGE", "The API is down for maintenance, try again later"),
+		AllowPaths: getEnvList("MAINTENANCE_ALLOW_PATHS", "/health,/version"),
+	}
+	if cfg.Maintenance.RetryAfter, err = getEnvDuration("MAINTENANCE_RETRY_AFTER", 5*time.Minute); err != nil {
+		return nil, err
+	}
+
+	if cfg.PII.Keys, err = getEnvKeys("PII_ENCRYPTION_KEYS"); err != nil {
+		return nil, err
+	}
+	cfg.PII.ActiveKeyID = getEnv("PII_ENCRYPTION_KEY_ID", "")
+
+	cfg.Events.LogTypes = getEnvList("EVENT_LOG_TYPES", "")
+	if cfg.Events.WebhookTimeout, err = getEnvDuration("WEBHOOK_TIMEOUT", 5*time.Second); err != nil {
+		return nil, err
+	}
+
+	cfg.Auth.JWTSecret = []byte(getEnv("JWT_SECRET", ""))
+	if cfg.Auth.TokenTTL, err = getEnvDuration("JWT_TTL", time.Hour); err != nil {
+		return nil, err
+	}
+	cfg.Auth.AdminEmail = strings.ToLower(strings.TrimSpace(getEnv("ADMIN_EMAIL", "")))
+	cfg.Auth.AdminPassword = getEnv("ADMIN_PASSWORD", "")
+
+	cfg.Info = InfoConfig{
+		Name:    getEnv("API_NAME", "job-tracker"),
+		DocsURL: getEnv("API_DOCS_URL", "/docs"),
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// loadApplicants reads the applicant rules
+func loadApplicants(a *ApplicantsConfig) error {
+	var err error
+	if a.StatusSLA, err = parseStatusSLA(getEnv("STATUS_SLA", "pending=72h,reviewed=5d,interviewed=7d")); err != nil {
+		return err
+	}
+	if a.MaxNotesLength, err = getEnvInt("MAX_NOTES_LENGTH", 5000); err != nil {
+		return err
+	}
+	if a.MaxResumeLength, err = getEnvInt("MAX_RESUME_LENGTH", 20000); err != nil {
+		return err
+	}
+	if a.MaxCommentLength, err = getEnvInt("MAX_COMMENT_LENGTH", 2000); err != nil {
+		return err
+	}
+	if a.MaxBatchSize, err = getEnvInt("MAX_BATCH_SIZE", 100); err != nil {
+		return err
+	}
+	if a.ExportChunkSize, err = getEnvInt("EXPORT_CHUNK_SIZE", 1000); err != nil {
+		return err
+	}
+	if a.CacheMaxAge, err = getEnvDuration("APPLICANT_CACHE_MAX_AGE", 30*time.Second); err != nil {
+		return err
+	}
+	if a.ReviewLockTTL, err = getEnvDuration("REVIEW_LOCK_TTL", 15*time.Minute); err != nil {
+		return err
+	}
+	a.UpdatableFields = lower(getEnvList("UPDATABLE_FIELDS", ""))
+	a.RequiredFields = lower(getEnvList("REQUIRED_FIELDS", ""))
+	a.DefaultStatus = utils.Status(strings.ToLower(getEnv("DEFAULT_STATUS", string(utils.StatusPending))))
+	a.DefaultCurrency = strings.ToUpper(getEnv("DEFAULT_CURRENCY", "USD"))
+	a.DefaultPhoneCountryCode = strings.TrimPrefix(getEnv("DEFAULT_PHONE_COUNTRY_CODE", ""), "+")
+	if a.RequirePhoneCountryCode, err = getEnvBool("REQUIRE_PHONE_COUNTRY_CODE", false); err != nil {
+		return err
+	}
+	a.EmailNormalizeProviders = lower(getEnvList("EMAIL_NORMALIZE_PROVIDERS", ""))
+	a.EmailReusePolicy = strings.ToLower(getEnv("EMAIL_REUSE_POLICY", EmailReuseReject))
+	a.ReopenStatus = utils.Status(strings.ToLower(getEnv("REOPEN_STATUS", string(utils.StatusReviewed))))
+	a.ReopenRoles = getEnvList("REOPEN_ROLES", "admin")
+	return nil
+}
+
+// validate checks relationships between settings that can't be checked individually
+func (cfg *Config) validate() error {
+	switch cfg.Server.Env {
+	case EnvDevelopment, EnvProduction:
+	default:
+		return fmt.Errorf("ENVIRONMENT must be development or production, got %q", cfg.Server.Env)
+	}
+	if !validPort(cfg.Server.Port) {
+		return fmt.Errorf("PORT must be a port number, got %q", cfg.Server.Port)
+	}
+
+	if cfg.Database.Host == "" || cfg.Database.User == "" || cfg.Database.Name == "" {
+		return fmt.Errorf("DB_HOST, DB_USER and DB_NAME must not be empty")
+	}
+	if !validPort(cfg.Database.Port) {
+		return fmt.Errorf("DB_PORT must be a port number, got %q", cfg.Database.Port)
+	}
+	if cfg.Server.Production() && cfg.Database.Password == "" {
+		return fmt.Errorf("DB_PASSWORD must be set in production")
+	}
+
+	if cfg.Redis.Host == "" {
+		return fmt.Errorf("REDIS_HOST must not be empty")
+	}
+	if !validPort(cfg.Redis.Port) {
+		return fmt.Errorf("REDIS_PORT must be a port number, got %q", cfg.Redis.Port)
+	}
+	if cfg.Cache.TTL < time.Second {
+		return fmt.Errorf("CACHE_TTL must be at least 1s, got %s", cfg.Cache.TTL)
+	}
+	if cfg.Cache.WarmPages <= 0 || cfg.Cache.WarmPages > MaxCacheWarmPages {
+		return fmt.Errorf("CACHE_WARM_PAGES must be between 1 and %d, got %d", MaxCacheWarmPages, cfg.Cache.WarmPages)
+	}
+
+	if err := cfg.Applicants.validate(); err != nil {
+		return err
+	}
+
+	pool := cfg.Database
+	if pool.MaxOpenConns <= 0 {
+		return fmt.Errorf("DB_MAX_OPEN_CONNS must be positive, got %d", pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns < 0 || pool.MaxIdleConns > pool.MaxOpenConns {
+		return fmt.Errorf("DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS (%d), got %d",
+			pool.MaxOpenConns, pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime < 0 {
+		return fmt.Errorf("DB_CONN_MAX_LIFETIME must not be negative, got %s", pool.ConnMaxLifetime)
+	}
+
+	jobs := cfg.Jobs
+	if jobs.Workers <= 0 {
+		return fmt.Errorf("JOB_WORKERS must be positive, got %d", jobs.Workers)
+	}
+	if jobs.QueueSize <= 0 {
+		return fmt.Errorf("JOB_QUEUE_SIZE must be positive, got %d", jobs.QueueSize)
+	}
+	if jobs.MaxAttempts <= 0 {
+		return fmt.Errorf("JOB_MAX_ATTEMPTS must be positive, got %d", jobs.MaxAttempts)
+	}
+	if jobs.RetryBackoff < 0 || jobs.DrainTimeout <= 0 {
+		return fmt.Errorf("JOB_RETRY_BACKOFF must not be negative and JOB_DRAIN_TIMEOUT must be positive")
+	}
+
+	if cfg.Events.WebhookTimeout <= 0 {
+		return fmt.Errorf("WEBHOOK_TIMEOUT must be positive, got %s", cfg.Events.WebhookTimeout)
+	}
+
+	if cfg.RateLimit.Requests < 0 {
+		return fmt.Errorf("RATE_LIMIT_REQUESTS must not be negative, got %d", cfg.RateLimit.Requests)
+	}
+	if cfg.RateLimit.Window < time.Second {
+		return fmt.Errorf("RATE_LIMIT_WINDOW must be at least 1s, got %s", cfg.RateLimit.Window)
+	}
+
+	upload := cfg.Upload
+	if upload.MaxConcurrent <= 0 {
+		return fmt.Errorf("UPLOAD_MAX_CONCURRENT must be positive, got %d", upload.MaxConcurrent)
+	}
+	if upload.MaxBytes <= 0 {
+		return fmt.Errorf("UPLOAD_MAX_BYTES must be positive, got %d", upload.MaxBytes)
+	}
+	if upload.RetryAfter < time.Second {
+		return fmt.Errorf("UPLOAD_RETRY_AFTER must be at least 1s, got %s", upload.RetryAfter)
+	}
+	if strings.TrimSpace(upload.Dir) == "" {
+		return fmt.Errorf("UPLOAD_DIR must not be empty")
+	}
+
+	maintenance := cfg.Maintenance
+	switch maintenance.Mode {
+	case MaintenanceOff, MaintenanceReadOnly, MaintenanceOn:
+	default:
+		return fmt.Errorf("MAINTENANCE_MODE must be off, read_only or on, got %q", maintenance.Mode)
+	}
+	if maintenance.RetryAfter < time.Second {
+		return fmt.Errorf("MAINTENANCE_RETRY_AFTER must be at least 1s, got %s", maintenance.RetryAfter)
+	}
+
+	if id := cfg.PII.ActiveKeyID; id != "" {
+		if _, ok := cfg.PII.Keys[id]; !ok {
+			return fmt.Errorf("PII_ENCRYPTION_KEY_ID %q is not listed in PII_ENCRYPTION_KEYS", id)
+		}
+	}
+
+	if len(cfg.Auth.JWTSecret) < minJWTSecretLength {
+		return fmt.Errorf("JWT_SECRET must be set to at least %d bytes", minJWTSecretLength)
+	}
+	if cfg.Auth.TokenTTL <= 0 {
+		return fmt.Errorf("JWT_TTL must be positive, got %s", cfg.Auth.TokenTTL)
+	}
+	if (cfg.Auth.AdminEmail == "") != (cfg.Auth.AdminPassword == "") {
+		return fmt.Errorf("ADMIN_EMAIL and ADMIN_PASSWORD must be set together")
+	}
+	return nil
+}
+
+// validate checks the applicant rules. Field names are checked against the
+// applicant schemas when the controllers load the rules.
+func (a *ApplicantsConfig) validate() error {
+	for key, value := range map[string]int{
+		"MAX_NOTES_LENGTH":   a.MaxNotesLength,
+		"MAX_RESUME_LENGTH":  a.MaxResumeLength,
+		"MAX_COMMENT_LENGTH": a.MaxCommentLength,
+		"MAX_BATCH_SIZE":     a.MaxBatchSize,
+		"EXPORT_CHUNK_SIZE":  a.ExportChunkSize,
+	} {
+		if value <= 0 {
+			return fmt.Errorf("%s must be positive, got %d", key, value)
+		}
+	}
+	if a.CacheMaxAge < 0 {
+		return fmt.Errorf("APPLICANT_CACHE_MAX_AGE must not be negative, got %s", a.CacheMaxAge)
+	}
+	if a.ReviewLockTTL <= 0 {
+		return fmt.Errorf("REVIEW_LOCK_TTL must be positive, got %s", a.ReviewLockTTL)
+	}
+	if !a.DefaultStatus.Valid() || a.DefaultStatus.IsTerminal() {
+		return fmt.Errorf("DEFAULT_STATUS must be a non-terminal status, got %q", a.DefaultStatus)
+	}
+	if !utils.ValidateCurrency(a.DefaultCurrency) {
+		return fmt.Errorf("DEFAULT_CURRENCY must be a three-letter currency code, got %q", a.DefaultCurrency)
+	}
+	if a.DefaultPhoneCountryCode != "" && !utils.ValidateCountryCallingCode(a.DefaultPhoneCountryCode) {
+		return fmt.Errorf("DEFAULT_PHONE_COUNTRY_CODE must be a country calling code, got %q", a.DefaultPhoneCountryCode)
+	}
+	switch a.EmailReusePolicy {
+	case EmailReuseReject, EmailReuseRestore, EmailReuseRelease:
+	default:
+		return fmt.Errorf("EMAIL_REUSE_POLICY must be reject, restore or release, got %q", a.EmailReusePolicy)
+	}
+	if !a.ReopenStatus.Valid() || a.ReopenStatus.IsTerminal() {
+		return fmt.Errorf("REOPEN_STATUS must be a non-terminal status, got %q", a.ReopenStatus)
+	}
+	if len(a.ReopenRoles) == 0 {
+		return fmt.Errorf("REOPEN_ROLES must list at least one role")
+	}
+	return nil
+}
+
+// validPort checks that port is a TCP port number
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// parseStatusMap parses a list like "hired=offer,rejected=decline" keyed by status
+func parseStatusMap(key, raw string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("%s: expected status=value, got %q", key, pair)
+		}
+		status := strings.ToLower(strings.TrimSpace(parts[0]))
+		if !utils.ValidateStatus(status) {
+			return nil, fmt.Errorf("%s: unknown status %q", key, status)
+		}
+		values[status] = strings.TrimSpace(parts[1])
+	}
+	return values, nil
+}
+
+// parseStatusSLA parses a list like "pending=72h,reviewed=5d" into per-status durations
+func parseStatusSLA(raw string) (map[string]time.Duration, error) {
+	sla := make(map[string]time.Duration)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("STATUS_SLA: expected status=duration, got %q", pair)
+		}
+
+		status := strings.ToLower(strings.TrimSpace(parts[0]))
+		if !utils.ValidateStatus(status) {
+			return nil, fmt.Errorf("STATUS_SLA: unknown status %q", status)
+		}
+
+		value := strings.TrimSpace(parts[1])
+		// time.ParseDuration has no day unit, so accept a plain "<n>d" as well
+		if strings.HasSuffix(value, "d") {
+			var days int
+			if _, err := fmt.Sscanf(value, "%dd", &days); err == nil {
+				value = fmt.Sprintf("%dh", days*24)
+			}
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("STATUS_SLA: invalid duration %q for status %q", parts[1], status)
+		}
+		sla[status] = duration
+	}
+	return sla, nil
+}
+
+// getEnvQuotas parses a list like "default=1000,admin=0" keyed by role
+func getEnvQuotas(key, defaultValue string) (map[string]int64, error) {
+	quotas := make(map[string]int64)
+	for _, pair := range strings.Split(getEnv(key, defaultValue), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%s: expected role=count, got %q", key, pair)
+		}
+		value, err := strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("%s: invalid quota %q for role %q", key, parts[1], parts[0])
+		}
+		quotas[strings.TrimSpace(parts[0])] = value
+	}
+	return quotas, nil
+}
+
+// getEnvKeys parses a list like "2024=<base64>,2023=<base64>" of 32-byte keys by id
+func getEnvKeys(key string) (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for _, pair := range strings.Split(getEnv(key, ""), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("%s: expected id=key", key)
+		}
+		id := strings.TrimSpace(parts[0])
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("%s: key id %q must not contain ':'", key, id)
+		}
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(parts[1]))
+		if err != nil || len(value) != 32 {
+			return nil, fmt.Errorf("%s: key %q must be 32 bytes, base64 encoded", key, id)
+		}
+		keys[id] = value
+	}
+	return keys, nil
+}
+
+// Helper function to get environment variable with default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvList parses a comma-separated list, dropping empty entries
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// lower lowercases every value of a list
+func lower(values []string) []string {
+	for i, value := range values {
+		values[i] = strings.ToLower(value)
+	}
+	return values
+}
+
+func getEnvBool(key string, defaultValue bool) (bool, error) {
+	raw := getEnv(key, "")
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false, got %q", key, raw)
+	}
+	return value, nil
+}
+
+func getEnvInt(key string, defaultValue int) (int, error) {
+	raw := getEnv(key, "")
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer, got %q", key, raw)
+	}
+	return value, nil
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	raw := getEnv(key, "")
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration like 30m or 1h, got %q", key, raw)
+	}
+	return value, nil
+}
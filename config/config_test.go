@@ -0,0 +1,155 @@
+package config
+
+import (
+	"job-tracker/utils"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setRequiredEnv sets the variables Load needs to succeed
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("JWT_SECRET", strings.Repeat("s", minJWTSecretLength))
+}
+
+func TestLoadApplicantDefaults(t *testing.T) {
+	setRequiredEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	a := cfg.Applicants
+	if a.StatusSLA["reviewed"] != 120*time.Hour {
+		t.Errorf("StatusSLA[reviewed] = %s, want 120h", a.StatusSLA["reviewed"])
+	}
+	if a.MaxCommentLength != 2000 {
+		t.Errorf("MaxCommentLength = %d, want 2000", a.MaxCommentLength)
+	}
+	if a.MaxBatchSize != 100 || a.ExportChunkSize != 1000 {
+		t.Errorf("MaxBatchSize, ExportChunkSize = %d, %d, want 100, 1000", a.MaxBatchSize, a.ExportChunkSize)
+	}
+	if a.DefaultStatus != utils.StatusPending || a.ReopenStatus != utils.StatusReviewed {
+		t.Errorf("DefaultStatus, ReopenStatus = %q, %q", a.DefaultStatus, a.ReopenStatus)
+	}
+	if a.EmailReusePolicy != EmailReuseReject {
+		t.Errorf("EmailReusePolicy = %q, want %q", a.EmailReusePolicy, EmailReuseReject)
+	}
+	if len(a.UpdatableFields) != 0 || len(a.RequiredFields) != 0 {
+		t.Errorf("UpdatableFields, RequiredFields = %v, %v, want both empty", a.UpdatableFields, a.RequiredFields)
+	}
+	if cfg.Cache.ReconcileOnStart || cfg.Cache.WarmPages != 1 {
+		t.Errorf("Cache = %+v, want no reconcile and 1 warm page", cfg.Cache)
+	}
+}
+
+func TestLoadApplicantSettings(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("STATUS_SLA", "Pending=2d")
+	t.Setenv("UPDATABLE_FIELDS", "Name, position")
+	t.Setenv("REQUIRED_FIELDS", " Phone ")
+	t.Setenv("DEFAULT_CURRENCY", "eur")
+	t.Setenv("DEFAULT_PHONE_COUNTRY_CODE", "+44")
+	t.Setenv("REQUIRE_PHONE_COUNTRY_CODE", "true")
+	t.Setenv("EMAIL_NORMALIZE_PROVIDERS", "Gmail.com,googlemail.com")
+	t.Setenv("RECONCILE_CACHE_ON_START", "true")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	a := cfg.Applicants
+	if len(a.StatusSLA) != 1 || a.StatusSLA["pending"] != 48*time.Hour {
+		t.Errorf("StatusSLA = %v, want pending=48h only", a.StatusSLA)
+	}
+	if strings.Join(a.UpdatableFields, ",") != "name,position" {
+		t.Errorf("UpdatableFields = %v", a.UpdatableFields)
+	}
+	if strings.Join(a.RequiredFields, ",") != "phone" {
+		t.Errorf("RequiredFields = %v, want phone", a.RequiredFields)
+	}
+	if a.DefaultCurrency != "EUR" || a.DefaultPhoneCountryCode != "44" || !a.RequirePhoneCountryCode {
+		t.Errorf("currency, country code, require = %q, %q, %v", a.DefaultCurrency, a.DefaultPhoneCountryCode, a.RequirePhoneCountryCode)
+	}
+	if strings.Join(a.EmailNormalizeProviders, ",") != "gmail.com,googlemail.com" {
+		t.Errorf("EmailNormalizeProviders = %v", a.EmailNormalizeProviders)
+	}
+	if !cfg.Cache.ReconcileOnStart {
+		t.Error("ReconcileOnStart = false, want true")
+	}
+}
+
+func TestLoadRejectsInvalidApplicantSettings(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+	}{
+		{"STATUS_SLA", "pending", "STATUS_SLA"},
+		{"STATUS_SLA", "archived=1h", "STATUS_SLA"},
+		{"STATUS_SLA", "pending=-1h", "STATUS_SLA"},
+		{"MAX_NOTES_LENGTH", "0", "MAX_NOTES_LENGTH"},
+		{"MAX_RESUME_LENGTH", "abc", "MAX_RESUME_LENGTH"},
+		{"MAX_COMMENT_LENGTH", "0", "MAX_COMMENT_LENGTH"},
+		{"MAX_COMMENT_LENGTH", "long", "MAX_COMMENT_LENGTH"},
+		{"MAX_BATCH_SIZE", "-5", "MAX_BATCH_SIZE"},
+		{"EXPORT_CHUNK_SIZE", "0", "EXPORT_CHUNK_SIZE"},
+		{"APPLICANT_CACHE_MAX_AGE", "-1s", "APPLICANT_CACHE_MAX_AGE"},
+		{"REVIEW_LOCK_TTL", "0s", "REVIEW_LOCK_TTL"},
+		{"RECONCILE_CACHE_ON_START", "maybe", "RECONCILE_CACHE_ON_START"},
+		{"CACHE_WARM_PAGES", "11", "CACHE_WARM_PAGES"},
+		{"CACHE_WARM_PAGES", "0", "CACHE_WARM_PAGES"},
+		{"DEFAULT_STATUS", "hired", "DEFAULT_STATUS"},
+		{"DEFAULT_STATUS", "archived", "DEFAULT_STATUS"},
+		{"DEFAULT_CURRENCY", "DOLLARS", "DEFAULT_CURRENCY"},
+		{"DEFAULT_PHONE_COUNTRY_CODE", "999", "DEFAULT_PHONE_COUNTRY_CODE"},
+		{"REQUIRE_PHONE_COUNTRY_CODE", "yes please", "REQUIRE_PHONE_COUNTRY_CODE"},
+		{"EMAIL_REUSE_POLICY", "merge", "EMAIL_REUSE_POLICY"},
+		{"REOPEN_STATUS", "rejected", "REOPEN_STATUS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			setRequiredEnv(t)
+			t.Setenv(tt.key, tt.value)
+
+			_, err := Load()
+			if err == nil {
+				t.Fatal("Load succeeded, want an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not name %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadMaintenance(t *testing.T) {
+	setRequiredEnv(t)
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	m := cfg.Maintenance
+	if m.Mode != MaintenanceOff || strings.Join(m.AllowPaths, ",") != "/health,/version" || m.RetryAfter != 5*time.Minute {
+		t.Errorf("default Maintenance = %+v, want off with /health and /version allowed", m)
+	}
+
+	t.Setenv("MAINTENANCE_MODE", "Read_Only")
+	t.Setenv("MAINTENANCE_ALLOW_PATHS", "/health, /metrics")
+	t.Setenv("MAINTENANCE_RETRY_AFTER", "30s")
+	if cfg, err = Load(); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	m = cfg.Maintenance
+	if m.Mode != MaintenanceReadOnly || strings.Join(m.AllowPaths, ",") != "/health,/metrics" || m.RetryAfter != 30*time.Second {
+		t.Errorf("Maintenance = %+v, want read_only with /health and /metrics allowed", m)
+	}
+
+	for key, value := range map[string]string{"MAINTENANCE_MODE": "paused", "MAINTENANCE_RETRY_AFTER": "500ms"} {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			if _, err := Load(); err == nil || !strings.Contains(err.Error(), key) {
+				t.Errorf("Load error = %v, want one naming %s", err, key)
+			}
+		})
+	}
+}
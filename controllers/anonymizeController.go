@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// anonymizedName replaces the name of anonymized applicants
+const anonymizedName = "Anonymized Applicant"
+
+// errAlreadyAnonymized is returned when an applicant has already been anonymized
+var errAlreadyAnonymized = errors.New("applicant already anonymized")
+
+// anonymizedEmail builds a placeholder email that is unique per applicant, so
+// the unique constraint holds, and uses the reserved .invalid domain so it can
+// never belong to a real candidate
+func anonymizedEmail(id uint) string {
+	return fmt.Sprintf("anonymized-%d@anonymized.invalid", id)
+}
+
+// AnonymizeApplicant redacts an applicant's personal data while keeping the
+// record, with its position and status history, for aggregate stats.
+// Anonymized applicants are left out of the normal listings.
+func AnonymizeApplicant(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var applicant models.Applicant
+	var resumeFile string
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&applicant, id).Error; err != nil {
+			return err
+		}
+		if applicant.AnonymizedAt != nil {
+			return errAlreadyAnonymized
+		}
+		resumeFile = applicant.ResumeFile
+
+		redacted := map[string]interface{}{
+			"name":            anonymizedName,
+			"email":           anonymizedEmail(applicant.ID),
+			"canonical_email": anonymizedEmail(applicant.ID),
+			"phone":           "",
+			"resume":          "",
+			"resume_file":     "",
+			"notes":           "",
+			"linked_in_url":   "",
+			"portfolio_url":   "",
+			"anonymized_at":   time.Now().UTC(),
+		}
+		if err := tx.Model(&applicant).Updates(redacted).Error; err != nil {
+			return err
+		}
+
+		// The audit entry names the redacted fields, by their JSON names, without
+		// keeping their old values
+		changes := make(map[string]models.FieldChange, len(redacted))
+		for column, value := range redacted {
+			if column == "canonical_email" {
+				continue
+			}
+			field := column
+			if column == "linked_in_url" {
+				field = "linkedin_url"
+			}
+			changes[field] = models.FieldChange{New: value}
+		}
+		return recordAudit(tx, applicant.ID, "anonymize", currentUser(c), changes, "")
+	})
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+	case errors.Is(err, errAlreadyAnonymized):
+		return c.Status(409).JSON(fiber.Map{"error": "Applicant is already anonymized"})
+	case err != nil:
+		log.Printf("Database error anonymizing applicant %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to anonymize applicant"})
+	}
+
+	if err := database.DB.Scopes(withTags).First(&applicant, id).Error; err != nil {
+		log.Printf("Database error reloading applicant %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load anonymized applicant"})
+	}
+
+	removeResumeFile(resumeFile)
+	invalidateApplicantListCache()
+	invalidateApplicantCache(id)
+	log.Printf("Anonymized applicant %d", id)
+
+	return c.JSON(applicant)
+}
@@ -3,31 +3,36 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"job-tracker/config"
 	"job-tracker/database"
+	"job-tracker/events"
+	"job-tracker/metrics"
+	"job-tracker/middleware"
 	"job-tracker/models"
+	"job-tracker/pii"
 	"job-tracker/utils"
 	"log"
-	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
 var ctx = context.Background()
 var rdb *redis.Client
 
-func InitRedis() {
-	// Get Redis configuration from environment variables
-	host := getEnv("REDIS_HOST", "localhost")
-	port := getEnv("REDIS_PORT", "6379")
-
+// InitRedis connects the shared Redis client. A failed connection is only
+// logged: Redis backs caches and counters, which fall back without it.
+func InitRedis(cfg config.RedisConfig) {
 	rdb = redis.NewClient(&redis.Options{
-		Addr:         fmt.Sprintf("%s:%s", host, port),
-		Password:     getEnv("REDIS_PASSWORD", ""),
+		Addr:         cfg.Addr(),
+		Password:     cfg.Password,
 		DB:           0,
 		PoolSize:     10,
 		MinIdleConns: 5,
@@ -42,143 +47,614 @@ func InitRedis() {
 	}
 }
 
-// Helper function to get environment variable with default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// RedisClient returns the shared Redis client, for middleware that keeps
+// counters in Redis. It is nil until InitRedis has run.
+func RedisClient() *redis.Client {
+	return rdb
+}
+
+// parseID reads the :id route parameter as a positive integer that fits the
+// bigint primary key column
+func parseID(c *fiber.Ctx) (uint, bool) {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 63)
+	if err != nil || id == 0 {
+		return 0, false
 	}
-	return defaultValue
+	return uint(id), true
 }
 
-func CreateApplicant(c *fiber.Ctx) error {
-	var applicant models.Applicant
-	if err := c.BodyParser(&applicant); err != nil {
-		log.Printf("Failed to parse request body: %v", err)
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+// cleanStatus sanitizes a status sent by a client. Statuses are stored
+// lowercase, so any casing is accepted.
+func cleanStatus(status utils.Status) utils.Status {
+	return utils.Status(strings.ToLower(utils.SanitizeString(string(status))))
+}
+
+// illegalTransition returns the 422 message for a status change the status
+// flow does not allow, or "" when from and to are the same or the move is legal
+func illegalTransition(from, to utils.Status) string {
+	if from == to || from.CanTransitionTo(to) {
+		return ""
+	}
+	return fmt.Sprintf("Cannot transition from %s to %s", from, to)
+}
+
+// canonicalPhone normalizes a phone number to E.164 with
+// DEFAULT_PHONE_COUNTRY_CODE, then applies the country code requirement when
+// the deployment enables it
+func canonicalPhone(phone string) (string, bool) {
+	normalized, err := utils.NormalizePhone(phone, defaultPhoneCountryCode)
+	if err != nil {
+		return "", false
+	}
+	if requirePhoneCountryCode && !utils.ValidateInternationalPhone(normalized) {
+		return "", false
+	}
+	return normalized, true
+}
+
+// normalizePhone moves an extension written into the phone number, such as
+// "+1 555 123 4567 x890", into PhoneExtension, stores the number in E.164 form
+// and validates both parts. It returns the field and error message for the
+// first invalid part.
+func normalizePhone(applicant *models.Applicant) (string, string, bool) {
+	applicant.PhoneExtension = utils.SanitizeString(applicant.PhoneExtension)
+	base, ext := utils.SplitPhoneExtension(utils.SanitizeString(string(applicant.Phone)))
+	if ext != "" {
+		if applicant.PhoneExtension != "" && applicant.PhoneExtension != ext {
+			return "phone_extension", "Phone extension conflicts with the extension in the phone number", false
+		}
+		applicant.PhoneExtension = ext
+	}
+	if base != "" {
+		normalized, ok := canonicalPhone(base)
+		if !ok {
+			return "phone", "Invalid phone number format", false
+		}
+		base = normalized
+	}
+	applicant.Phone = pii.EncryptedString(base)
+
+	if applicant.PhoneExtension != "" && !utils.ValidatePhoneExtension(applicant.PhoneExtension) {
+		return "phone_extension", "Invalid phone extension format", false
+	}
+	return "", "", true
+}
+
+// validateTextLengths enforces the configured size limits on the free-text
+// fields, returning an error for every field that is too long
+func validateTextLengths(applicant *models.Applicant) []utils.FieldError {
+	var errs []utils.FieldError
+	for _, text := range []struct {
+		field string
+		value string
+		max   int
+	}{
+		{"notes", applicant.Notes, maxNotesLength},
+		{"resume", string(applicant.Resume), maxResumeLength},
+	} {
+		if !utils.ValidateMaxLength(text.value, text.max) {
+			errs = append(errs, utils.FieldError{Field: text.field, Message: fmt.Sprintf("%s must be at most %d characters", text.field, text.max)})
+		}
+	}
+	return errs
+}
+
+// maxURLLength bounds the profile link fields
+const maxURLLength = 500
+
+// validateURLs checks the optional profile links, returning an error for
+// every field that is not a valid http(s) URL
+func validateURLs(applicant *models.Applicant) []utils.FieldError {
+	var errs []utils.FieldError
+	for _, link := range []struct{ field, value string }{
+		{"linkedin_url", applicant.LinkedInURL},
+		{"portfolio_url", applicant.PortfolioURL},
+	} {
+		if link.value != "" && !utils.ValidateURL(link.value, maxURLLength) {
+			errs = append(errs, utils.FieldError{Field: link.field, Message: fmt.Sprintf("%s must be an http or https URL of at most %d characters", link.field, maxURLLength)})
+		}
+	}
+	return errs
+}
+
+// respondFieldErrors sends a 422 naming the first invalid field in error and
+// field, as single-field update errors do, and listing every one in errors
+func respondFieldErrors(c *fiber.Ctx, errs []utils.FieldError) error {
+	return c.Status(422).JSON(fiber.Map{"error": errs[0].Message, "field": errs[0].Field, "errors": errs})
+}
+
+// disallowedUpdateField returns the first field in an update body, in
+// alphabetical order, that UPDATABLE_FIELDS does not allow to change
+func disallowedUpdateField(body []byte) (string, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return "", false
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !updatableFields[name] {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// normalizeSalary validates the expected salary and its currency, filling in
+// DEFAULT_CURRENCY for a salary given without one. It returns the name of the
+// first invalid field and the reason.
+func normalizeSalary(applicant *models.Applicant) (string, string, bool) {
+	applicant.SalaryCurrency = strings.ToUpper(utils.SanitizeString(applicant.SalaryCurrency))
+	if applicant.ExpectedSalary != nil && *applicant.ExpectedSalary < 0 {
+		return "expected_salary", "expected_salary must not be negative", false
 	}
+	if applicant.SalaryCurrency != "" && !utils.ValidateCurrency(applicant.SalaryCurrency) {
+		return "salary_currency", "salary_currency must be a three-letter ISO 4217 code", false
+	}
+	if applicant.ExpectedSalary != nil && applicant.SalaryCurrency == "" {
+		applicant.SalaryCurrency = defaultCurrency
+	}
+	return "", "", true
+}
+
+// applicantWithChanges is an applicant response that also lists the fields the
+// request changed, returned when the client passes include_changes=true
+type applicantWithChanges struct {
+	models.Applicant
+	Changes map[string]models.FieldChange `json:"changes"`
+}
+
+// respondWithChanges sends the applicant, adding the changes when requested
+func respondWithChanges(c *fiber.Ctx, status int, applicant models.Applicant, changes map[string]models.FieldChange) error {
+	if !c.QueryBool("include_changes") {
+		return c.Status(status).JSON(applicant)
+	}
+	if changes == nil {
+		changes = map[string]models.FieldChange{}
+	}
+	return c.Status(status).JSON(applicantWithChanges{Applicant: applicant, Changes: changes})
+}
 
+// prepareNewApplicant sanitizes and validates an applicant about to be
+// created, filling in the default status and the canonical email. Every field
+// is checked independently and all problems are returned, so a client can fix
+// a form in one pass. It does not check whether the email is taken.
+func prepareNewApplicant(applicant *models.Applicant) []utils.FieldError {
 	// Sanitize input
 	applicant.Name = utils.SanitizeString(applicant.Name)
 	applicant.Email = strings.ToLower(utils.SanitizeString(applicant.Email))
 	applicant.Position = utils.SanitizeString(applicant.Position)
-	applicant.Phone = utils.SanitizeString(applicant.Phone)
 	applicant.Notes = utils.SanitizeString(applicant.Notes)
+	applicant.LinkedInURL = utils.SanitizeString(applicant.LinkedInURL)
+	applicant.PortfolioURL = utils.SanitizeString(applicant.PortfolioURL)
+	applicant.Status = cleanStatus(applicant.Status)
+	// Tags are added through POST /applicants/:id/tags, never with the applicant
+	applicant.Tags = nil
+
+	var errs []utils.FieldError
+	invalid := func(field, message string) {
+		errs = append(errs, utils.FieldError{Field: field, Message: message})
+	}
 
-	// Validate required fields
-	if applicant.Name == "" || applicant.Email == "" || applicant.Position == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "Name, email, and position are required"})
+	if applicant.Name == "" {
+		invalid("name", "name must not be empty")
 	}
 
-	// Validate email format
 	if !utils.ValidateEmail(applicant.Email) {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid email format"})
+		invalid("email", "Invalid email format")
 	}
 
-	// Validate phone if provided
-	if applicant.Phone != "" && !utils.ValidatePhone(applicant.Phone) {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid phone number format"})
+	// Validate phone and extension if provided
+	if field, message, ok := normalizePhone(applicant); !ok {
+		invalid(field, message)
 	}
 
-	// Set default status if not provided
-	if applicant.Status == "" {
-		applicant.Status = "pending"
-	} else if !utils.ValidateStatus(applicant.Status) {
-		return c.Status(400).JSON(fiber.Map{"error": "Invalid status value"})
+	// Validate free-text field sizes
+	errs = append(errs, validateTextLengths(applicant)...)
+
+	if err := utils.ValidatePosition(applicant.Position); err != nil {
+		invalid("position", "position "+err.Error())
 	}
 
-	// Check if email already exists
+	// Validate expected salary if provided
+	if field, message, ok := normalizeSalary(applicant); !ok {
+		invalid(field, message)
+	}
+
+	// Validate profile links if provided
+	errs = append(errs, validateURLs(applicant)...)
+
+	// New applicants enter the pipeline at DEFAULT_STATUS; later statuses
+	// are reached through updates and transitions
+	switch {
+	case applicant.Status == "":
+		applicant.Status = defaultStatus
+	case !applicant.Status.Valid():
+		invalid("status", "Invalid status value")
+	case applicant.Status != defaultStatus:
+		invalid("status", fmt.Sprintf("status must be %s or unset on create", defaultStatus))
+	}
+
+	if len(errs) > 0 {
+		sort.SliceStable(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+		return errs
+	}
+	applicant.StatusChangedAt = time.Now().UTC()
+	applicant.CanonicalEmail = canonicalEmail(applicant.Email)
+	return nil
+}
+
+func CreateApplicant(c *fiber.Ctx) error {
+	var applicant models.Applicant
+	if err := c.BodyParser(&applicant); err != nil {
+		log.Printf("[%s] Failed to parse request body: %v", middleware.RequestID(c), err)
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	// Required fields are checked by middleware.ValidateBody(ApplicantCreateSchema)
+	if errs := prepareNewApplicant(&applicant); len(errs) > 0 {
+		return c.Status(422).JSON(fiber.Map{"errors": errs})
+	}
+
+	// Check if email already exists, including provider variants of it. The
+	// unique constraint only covers the exact email, so this check stays; the
+	// insert below still handles a concurrent create that slips past it.
 	var existingApplicant models.Applicant
-	if err := database.DB.Where("email = ?", applicant.Email).First(&existingApplicant).Error; err == nil {
+	if err := emailQuery(database.DB, applicant.Email).First(&existingApplicant).Error; err == nil {
 		return c.Status(409).JSON(fiber.Map{"error": "Email already exists"})
 	}
 
-	if err := database.DB.Create(&applicant).Error; err != nil {
-		log.Printf("Database error creating applicant: %v", err)
+	// A soft-deleted applicant may still hold the email; EMAIL_REUSE_POLICY decides what happens
+	changes := map[string]models.FieldChange{}
+	restored := false
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if changes, restored, err = reuseDeletedEmail(tx, &applicant); err != nil {
+			return err
+		}
+		if restored {
+			return recordAudit(tx, applicant.ID, "restore", currentUser(c), changes, "reapplied with the same email")
+		}
+
+		if err := tx.Create(&applicant).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, applicant.ID, "create", currentUser(c), nil, "")
+	})
+	if errors.Is(err, errEmailHeldByDeleted) {
+		return c.Status(409).JSON(fiber.Map{"error": "Email belongs to a deleted applicant"})
+	}
+	// Another request created the same email between the check and the insert
+	if database.IsUniqueViolation(err) {
+		return c.Status(409).JSON(fiber.Map{"error": "Email already exists"})
+	}
+	if err != nil {
+		log.Printf("[%s] Database error creating applicant: %v", middleware.RequestID(c), err)
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to create applicant"})
 	}
 
 	// Clear cache to ensure fresh data on next request
-	// Note: In production, we'd use pattern matching to clear all paginated cache
-	rdb.Del(ctx, "applicants_page_1_limit_10", "applicants_page_1_limit_20") // Clear common cache keys
-	log.Printf("Created new applicant with ID: %d", applicant.ID)
+	invalidateApplicantListCache()
+	publishEvent(events.ApplicantCreated, applicant, currentUser(c), changes)
+	if restored {
+		log.Printf("Restored deleted applicant with ID: %d", applicant.ID)
+	} else {
+		rdb.Incr(ctx, createdCounterKey)
+		log.Printf("Created new applicant with ID: %d", applicant.ID)
+	}
 
-	return c.Status(201).JSON(applicant)
+	return respondWithChanges(c, 201, applicant, changes)
 }
 
 func GetApplicants(c *fiber.Ctx) error {
 	// Get query parameters for pagination
-	page := c.Query("page", "1")
-	limit := c.Query("limit", "10")
+	pageInt, limitInt, err := parsePagination(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 
-	pageInt, _ := strconv.Atoi(page)
-	limitInt, _ := strconv.Atoi(limit)
+	// Incremental sync requests always read from the database
+	if since := c.Query("updated_since"); since != "" {
+		return getApplicantChanges(c, since, pageInt, limitInt)
+	}
 
-	// Create cache key with pagination
-	cacheKey := fmt.Sprintf("applicants_page_%d_limit_%d", pageInt, limitInt)
+	// Cursor pagination is opt-in; offset pages stay the default
+	if raw, ok := c.Queries()["cursor"]; ok {
+		return getApplicantsByCursor(c, raw, limitInt)
+	}
 
-	val, err := rdb.Get(ctx, cacheKey).Result()
+	filters, err := parseApplicantFilters(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 
-	if err == redis.Nil {
-		// Cache miss - fetch from database
-		var applicants []models.Applicant
-		offset := (pageInt - 1) * limitInt
+	order, err := parseApplicantSort(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
 
-		if err := database.DB.Offset(offset).Limit(limitInt).Find(&applicants).Error; err != nil {
-			log.Printf("Database error: %v", err)
-			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicants"})
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Create cache key with pagination, filters and order
+	cacheKey := fmt.Sprintf("applicants_page_%d_limit_%d", pageInt, limitInt) + filters.cacheKeySuffix() + order.cacheKeySuffix()
+
+	// The cache never fails the request: any Redis or encoding problem is
+	// logged and counted, and the page is served from the database
+	val, err := rdb.Get(ctx, cacheKey).Result()
+	switch {
+	case err == nil:
+		var cached applicantPage
+		err := json.Unmarshal([]byte(val), &cached)
+		if err == nil {
+			recordCacheLookup(true)
+			log.Printf("Cache hit - returned %d applicants", len(cached.Data))
+			return respondApplicantPage(c, cached, pageInt, limitInt, loc)
 		}
+		log.Printf("Cache decode error for key %s: %v", cacheKey, err)
+		metrics.CacheErrors.WithLabelValues("decode").Inc()
+	case err == redis.Nil:
+		recordCacheLookup(false)
+	default:
+		log.Printf("Redis read error for key %s: %v", cacheKey, err)
+		metrics.CacheErrors.WithLabelValues("read").Inc()
+	}
 
-		// Cache the result for 3 minutes
-		jsonData, _ := json.Marshal(applicants)
-		rdb.Set(ctx, cacheKey, jsonData, time.Minute*3)
+	page, err := loadApplicantPage(filters, order, pageInt, limitInt)
+	if err != nil {
+		log.Printf("[%s] Database error: %v", middleware.RequestID(c), err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicants"})
+	}
 
-		log.Printf("Cache miss - fetched %d applicants from database", len(applicants))
-		return c.JSON(fiber.Map{
-			"data":  applicants,
-			"page":  pageInt,
-			"limit": limitInt,
-		})
+	// Cache the page and its total for listCacheTTL
+	if jsonData, err := json.Marshal(page); err != nil {
+		log.Printf("Cache encode error for key %s: %v", cacheKey, err)
+		metrics.CacheErrors.WithLabelValues("encode").Inc()
+	} else if err := rdb.Set(ctx, cacheKey, jsonData, listCacheTTL).Err(); err != nil {
+		log.Printf("Redis write error for key %s: %v", cacheKey, err)
+		metrics.CacheErrors.WithLabelValues("write").Inc()
+	}
+
+	log.Printf("Cache miss - fetched %d applicants from database", len(page.Data))
+	return respondApplicantPage(c, page, pageInt, limitInt, loc)
+}
+
+// respondApplicantPage writes a list page with its pagination totals, also
+// reporting the total in the X-Total-Count header
+func respondApplicantPage(c *fiber.Ctx, page applicantPage, pageInt, limitInt int, loc *time.Location) error {
+	localizeApplicants(page.Data, loc)
+	c.Set(totalCountHeader, strconv.FormatInt(page.Total, 10))
+	return c.JSON(fiber.Map{
+		"data":        page.Data,
+		"pagination":  "offset",
+		"page":        pageInt,
+		"limit":       limitInt,
+		"total":       page.Total,
+		"total_pages": page.totalPages(limitInt),
+	})
+}
 
-	} else if err != nil {
-		log.Printf("Redis error: %v", err)
-		// Fallback to database if Redis fails
-		var applicants []models.Applicant
-		offset := (pageInt - 1) * limitInt
-		if err := database.DB.Offset(offset).Limit(limitInt).Find(&applicants).Error; err != nil {
+// getApplicantsByCursor returns the applicants after the cursor in id order,
+// with the list filters applied. Unlike offset pages it stays fast deep into
+// the table and never repeats or skips a row when applicants are added or
+// removed between requests. next_cursor is null on the last page. Cursor
+// pages are not cached and carry no total.
+func getApplicantsByCursor(c *fiber.Ctx, raw string, limit int) error {
+	if c.Query("page") != "" || c.Query("sort") != "" || c.Query("order") != "" {
+		return c.Status(400).JSON(fiber.Map{"error": "cursor cannot be combined with page, sort or order"})
+	}
+	cursor, err := parseCursor(raw)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	filters, err := parseApplicantFilters(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// One extra row tells whether another page follows
+	applicants := []models.Applicant{}
+	if err := applicantQuery(filters).Scopes(withTags).
+		Where("id > ?", cursor).
+		Order("id ASC").
+		Limit(limit + 1).
+		Find(&applicants).Error; err != nil {
+		log.Printf("[%s] Database error fetching applicants after cursor %d: %v", middleware.RequestID(c), cursor, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicants"})
+	}
+
+	var next interface{}
+	if len(applicants) > limit {
+		applicants = applicants[:limit]
+		next = strconv.FormatUint(uint64(applicants[limit-1].ID), 10)
+	}
+
+	localizeApplicants(applicants, loc)
+	return c.JSON(fiber.Map{
+		"data":        applicants,
+		"pagination":  "cursor",
+		"cursor":      strconv.FormatUint(uint64(cursor), 10),
+		"limit":       limit,
+		"next_cursor": next,
+	})
+}
+
+// maxTombstones caps the deleted list of the change feed
+const maxTombstones = 1000
+
+// getApplicantChanges returns applicants modified after the given RFC3339
+// timestamp, oldest change first, so external mirrors can poll for updates.
+// With include_deleted every page also lists the records soft-deleted since
+// then, up to maxTombstones; deleted_truncated reports that there are more.
+func getApplicantChanges(c *fiber.Ctx, since string, pageInt, limitInt int) error {
+	sinceTime, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "updated_since must be an RFC3339 timestamp"})
+	}
+
+	applicants := []models.Applicant{}
+	offset := (pageInt - 1) * limitInt
+	// Anonymized applicants stay in the feed so clients can drop their copies
+	if err := applicantQuery(applicantFilters{IncludeAnonymized: true}).Scopes(withTags).Where("updated_at > ?", sinceTime).
+		Order("updated_at ASC, id ASC").
+		Offset(offset).Limit(limitInt).
+		Find(&applicants).Error; err != nil {
+		log.Printf("[%s] Database error fetching changed applicants: %v", middleware.RequestID(c), err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicants"})
+	}
+
+	response := fiber.Map{
+		"data":          applicants,
+		"page":          pageInt,
+		"limit":         limitInt,
+		"updated_since": sinceTime.UTC(),
+	}
+
+	if c.QueryBool("include_deleted") {
+		type tombstone struct {
+			ID        uint      `json:"id"`
+			DeletedAt time.Time `json:"deleted_at"`
+		}
+		deleted := []tombstone{}
+		if err := applicantQuery(applicantFilters{IncludeDeleted: true, IncludeAnonymized: true}).
+			Select("id", "deleted_at").
+			Where("deleted_at > ?", sinceTime).
+			Order("deleted_at ASC, id ASC").
+			Limit(maxTombstones + 1).
+			Find(&deleted).Error; err != nil {
+			log.Printf("[%s] Database error fetching deleted applicants: %v", middleware.RequestID(c), err)
 			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicants"})
 		}
-		return c.JSON(fiber.Map{
-			"data":  applicants,
-			"page":  pageInt,
-			"limit": limitInt,
-		})
+		truncated := len(deleted) > maxTombstones
+		if truncated {
+			deleted = deleted[:maxTombstones]
+		}
+		response["deleted"] = deleted
+		response["deleted_truncated"] = truncated
 	}
 
-	// Cache hit
-	var applicants []models.Applicant
-	json.Unmarshal([]byte(val), &applicants)
-	log.Printf("Cache hit - returned %d applicants", len(applicants))
+	return c.JSON(response)
+}
+
+// GetOverdueApplicants lists applicants that have stayed in their current status
+// longer than the SLA configured for that status
+func GetOverdueApplicants(c *fiber.Ctx) error {
+	filters, err := parseApplicantFilters(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	now := time.Now().UTC()
 
+	var conditions []string
+	var args []interface{}
+	for status, sla := range statusSLA {
+		conditions = append(conditions, "(status = ? AND status_changed_at < ?)")
+		args = append(args, status, now.Add(-sla))
+	}
+
+	applicants := []models.Applicant{}
+	if len(conditions) > 0 {
+		if err := applicantQuery(filters).Scopes(withTags).Where(strings.Join(conditions, " OR "), args...).
+			Order("status_changed_at ASC, id ASC").
+			Find(&applicants).Error; err != nil {
+			log.Printf("[%s] Database error fetching overdue applicants: %v", middleware.RequestID(c), err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch overdue applicants"})
+		}
+	}
+
+	localizeApplicants(applicants, loc)
 	return c.JSON(fiber.Map{
 		"data":  applicants,
-		"page":  pageInt,
-		"limit": limitInt,
+		"count": len(applicants),
 	})
 }
 
 func GetApplicant(c *fiber.Ctx) error {
-	id := c.Params("id")
-	var applicant models.Applicant
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
 
-	if err := database.DB.First(&applicant, id).Error; err != nil {
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	applicant, err := loadCachedApplicant(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
 	}
+	if err != nil {
+		log.Printf("[%s] Database error fetching applicant %d: %v", middleware.RequestID(c), id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicant"})
+	}
 
+	if setCacheHeaders(c, applicant.UpdatedAt) {
+		return c.SendStatus(304)
+	}
+
+	localizeApplicant(&applicant, loc)
 	return c.JSON(applicant)
 }
 
+// loadCachedApplicant reads an applicant through the applicantCacheTTL cache.
+// Like the list cache it never fails the read: Redis and encoding problems are
+// logged and counted and the applicant is read from the database. Only found
+// applicants are cached, so a missing id keeps returning 404.
+func loadCachedApplicant(id uint) (models.Applicant, error) {
+	var applicant models.Applicant
+	cacheKey := applicantCacheKey(id)
+
+	val, err := rdb.Get(ctx, cacheKey).Result()
+	switch {
+	case err == nil:
+		err := json.Unmarshal([]byte(val), &applicant)
+		if err == nil {
+			return applicant, nil
+		}
+		log.Printf("Cache decode error for key %s: %v", cacheKey, err)
+		metrics.CacheErrors.WithLabelValues("decode").Inc()
+		applicant = models.Applicant{}
+	case err == redis.Nil:
+	default:
+		log.Printf("Redis read error for key %s: %v", cacheKey, err)
+		metrics.CacheErrors.WithLabelValues("read").Inc()
+	}
+
+	if err := database.DB.Scopes(withTags).First(&applicant, id).Error; err != nil {
+		return applicant, err
+	}
+
+	if jsonData, err := json.Marshal(applicant); err != nil {
+		log.Printf("Cache encode error for key %s: %v", cacheKey, err)
+		metrics.CacheErrors.WithLabelValues("encode").Inc()
+	} else if err := rdb.Set(ctx, cacheKey, jsonData, applicantCacheTTL).Err(); err != nil {
+		log.Printf("Redis write error for key %s: %v", cacheKey, err)
+		metrics.CacheErrors.WithLabelValues("write").Inc()
+	}
+	return applicant, nil
+}
+
 func UpdateApplicant(c *fiber.Ctx) error {
-	id := c.Params("id")
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
 	var applicant models.Applicant
 
 	// Check if applicant exists
@@ -192,31 +668,187 @@ func UpdateApplicant(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
-	// Update applicant
-	if err := database.DB.Model(&applicant).Updates(updateData).Error; err != nil {
+	// Only the fields allowed by UPDATABLE_FIELDS may be sent
+	if field, found := disallowedUpdateField(c.Body()); found {
+		return c.Status(422).JSON(fiber.Map{
+			"error": fmt.Sprintf("%s may not be updated", field),
+			"field": field,
+		})
+	}
+
+	updateData.Name = utils.SanitizeString(updateData.Name)
+
+	// Normalize and check a new email the same way create does. Deleted
+	// applicants still hold their email in the unique constraint.
+	updateData.Email = strings.ToLower(utils.SanitizeString(updateData.Email))
+	if updateData.Email != "" && updateData.Email != applicant.Email {
+		if !utils.ValidateEmail(updateData.Email) {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid email format"})
+		}
+		updateData.CanonicalEmail = canonicalEmail(updateData.Email)
+		taken, err := emailTakenByOther(updateData.Email, applicant.ID)
+		if err != nil {
+			log.Printf("[%s] Database error checking email: %v", middleware.RequestID(c), err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to update applicant"})
+		}
+		if taken {
+			return c.Status(409).JSON(fiber.Map{"error": "Email already exists"})
+		}
+	}
+
+	updateData.Status = cleanStatus(updateData.Status)
+	if updateData.Status != "" {
+		if !updateData.Status.Valid() {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid status value"})
+		}
+		if message := illegalTransition(applicant.Status, updateData.Status); message != "" {
+			return c.Status(422).JSON(fiber.Map{"error": message, "field": "status"})
+		}
+	}
+
+	// Validate phone and extension if provided
+	if _, message, ok := normalizePhone(&updateData); !ok {
+		return c.Status(400).JSON(fiber.Map{"error": message})
+	}
+
+	// Validate free-text field sizes
+	if errs := validateTextLengths(&updateData); len(errs) > 0 {
+		return respondFieldErrors(c, errs)
+	}
+
+	// Validate position format if provided
+	updateData.Position = utils.SanitizeString(updateData.Position)
+	if updateData.Position != "" {
+		if err := utils.ValidatePosition(updateData.Position); err != nil {
+			return c.Status(422).JSON(fiber.Map{"error": "position " + err.Error(), "field": "position"})
+		}
+	}
+
+	// Validate expected salary if provided, keeping the stored currency when
+	// only the amount changes
+	if updateData.ExpectedSalary != nil && utils.SanitizeString(updateData.SalaryCurrency) == "" {
+		updateData.SalaryCurrency = applicant.SalaryCurrency
+	}
+	if field, message, ok := normalizeSalary(&updateData); !ok {
+		return c.Status(422).JSON(fiber.Map{"error": message, "field": field})
+	}
+
+	// Validate profile links if provided
+	updateData.LinkedInURL = utils.SanitizeString(updateData.LinkedInURL)
+	updateData.PortfolioURL = utils.SanitizeString(updateData.PortfolioURL)
+	if errs := validateURLs(&updateData); len(errs) > 0 {
+		return respondFieldErrors(c, errs)
+	}
+
+	// Track when the applicant entered its current status
+	if updateData.Status != "" && updateData.Status != applicant.Status {
+		updateData.StatusChangedAt = time.Now().UTC()
+	}
+
+	return saveApplicantUpdate(c, applicant, func(tx *gorm.DB, applicant *models.Applicant) error {
+		return tx.Model(applicant).Updates(updateData).Error
+	})
+}
+
+// emailTakenByOther reports whether another applicant, deleted or not, already
+// uses the email
+func emailTakenByOther(email string, id uint) (bool, error) {
+	var taken int64
+	err := emailQuery(database.DB.Unscoped().Model(&models.Applicant{}), email).
+		Where("id <> ?", id).
+		Count(&taken).Error
+	return taken > 0, err
+}
+
+// saveApplicantUpdate runs update in a transaction, records what changed in
+// the audit log and responds with the persisted applicant. It is shared by
+// PUT and PATCH, which differ only in how they build the update.
+func saveApplicantUpdate(c *fiber.Ctx, applicant models.Applicant, update func(tx *gorm.DB, applicant *models.Applicant) error) error {
+	before := applicant
+	var changes map[string]models.FieldChange
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := update(tx, &applicant); err != nil {
+			return err
+		}
+
+		// Re-fetch so the response reflects the persisted row, including the new updated_at
+		if err := tx.Scopes(withTags).First(&applicant, applicant.ID).Error; err != nil {
+			return err
+		}
+
+		changes = models.DiffApplicants(before, applicant)
+		if len(changes) == 0 {
+			return nil
+		}
+		if err := recordAudit(tx, applicant.ID, "update", currentUser(c), changes, ""); err != nil {
+			return err
+		}
+		if applicant.Status == before.Status {
+			return nil
+		}
+		if err := recordStatusChange(tx, applicant.ID, before.Status, applicant.Status, currentUser(c)); err != nil {
+			return err
+		}
+
+		// A status change made here runs the same workflow rules as a transition
+		ruleChanges, err := applyWorkflow(tx, &applicant, before.Status, applicant.Status, currentUser(c))
+		if err != nil || len(ruleChanges) == 0 {
+			return err
+		}
+		return tx.Scopes(withTags).First(&applicant, applicant.ID).Error
+	})
+	// Another request may have taken the email since the check above
+	if database.IsUniqueViolation(err) {
+		return c.Status(409).JSON(fiber.Map{"error": "Email already exists"})
+	}
+	if err != nil {
+		log.Printf("[%s] Database error updating applicant %d: %v", middleware.RequestID(c), applicant.ID, err)
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to update applicant"})
 	}
 
-	// Clear cache - TODO: implement proper cache invalidation
-	rdb.Del(ctx, "applicants_page_1_limit_10", "applicants_page_1_limit_20")
-	return c.JSON(applicant)
+	// Clear cache to ensure fresh data on next request
+	invalidateApplicantListCache()
+	invalidateApplicantCache(applicant.ID)
+	releaseReviewLock(applicant.ID)
+	if len(changes) > 0 {
+		publishEvent(events.ApplicantUpdated, applicant, currentUser(c), changes)
+	}
+	if applicant.Status != before.Status {
+		notifyStatusChange(applicant, before.Status, currentUser(c))
+	}
+	return respondWithChanges(c, 200, applicant, changes)
 }
 
+// DeleteApplicant soft-deletes an applicant. It runs under the request
+// transaction, so the delete and its audit entry are saved together.
 func DeleteApplicant(c *fiber.Ctx) error {
-	id := c.Params("id")
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+	db := middleware.DB(c)
 	var applicant models.Applicant
 
 	// Check if applicant exists
-	if err := database.DB.First(&applicant, id).Error; err != nil {
+	if err := db.First(&applicant, id).Error; err != nil {
 		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
 	}
 
 	// Delete applicant
-	if err := database.DB.Delete(&applicant).Error; err != nil {
+	if err := db.Delete(&applicant).Error; err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete applicant"})
+	}
+	if err := recordAudit(db, applicant.ID, "delete", currentUser(c), nil, ""); err != nil {
+		log.Printf("[%s] Database error auditing delete of applicant %d: %v", middleware.RequestID(c), applicant.ID, err)
 		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete applicant"})
 	}
 
-	// Clear cache
-	rdb.Del(ctx, "applicants_page_1_limit_10", "applicants_page_1_limit_20")
+	// Clear cache once the delete is saved
+	middleware.AfterCommit(c, func() {
+		invalidateApplicantListCache()
+		invalidateApplicantCache(applicant.ID)
+		releaseReviewLock(applicant.ID)
+		publishEvent(events.ApplicantDeleted, applicant, currentUser(c), nil)
+	})
 	return c.Status(200).JSON(fiber.Map{"message": "Applicant deleted successfully"})
 }
@@ -0,0 +1,646 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/metrics"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// changesPage is a page of the change feed with its tombstones
+type changesPage struct {
+	Data    []models.Applicant `json:"data"`
+	Deleted []struct {
+		ID uint `json:"id"`
+	} `json:"deleted"`
+	DeletedTruncated bool `json:"deleted_truncated"`
+}
+
+func getChangesPage(t *testing.T, app *fiber.App, since time.Time, query string) changesPage {
+	t.Helper()
+	target := fmt.Sprintf("/applicants?updated_since=%s&include_deleted=true&%s", url.QueryEscape(since.Format(time.RFC3339)), query)
+	resp, body := doRequest(t, app, newJSONRequest(t, "GET", target, nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("%s: status = %d, body %s", query, resp.StatusCode, body)
+	}
+	var got changesPage
+	decodeJSON(t, body, &got)
+	return got
+}
+
+func TestApplicantChangesListsEveryTombstone(t *testing.T) {
+	useTestBackends(t)
+	since := time.Now().UTC().Add(-time.Minute)
+	kept := createTestApplicant(t, models.Applicant{})
+	var deletedIDs []uint
+	for i := 0; i < 3; i++ {
+		applicant := createTestApplicant(t, models.Applicant{})
+		if err := database.DB.Delete(&applicant).Error; err != nil {
+			t.Fatal(err)
+		}
+		deletedIDs = append(deletedIDs, applicant.ID)
+	}
+	app := newApplicantTestApp()
+
+	// page and limit only apply to data; each page lists every tombstone
+	for n, wantData := range []int{1, 0} {
+		got := getChangesPage(t, app, since, fmt.Sprintf("limit=2&page=%d", n+1))
+		if len(got.Data) != wantData || (wantData == 1 && got.Data[0].ID != kept.ID) {
+			t.Errorf("page %d data = %+v, want %d applicants", n+1, got.Data, wantData)
+		}
+		var seen []uint
+		for _, tombstone := range got.Deleted {
+			seen = append(seen, tombstone.ID)
+		}
+		if fmt.Sprint(seen) != fmt.Sprint(deletedIDs) || got.DeletedTruncated {
+			t.Errorf("page %d tombstones = %v (truncated %v), want %v in delete order", n+1, seen, got.DeletedTruncated, deletedIDs)
+		}
+	}
+}
+
+func TestApplicantChangesCapsTombstones(t *testing.T) {
+	useTestBackends(t)
+	since := time.Now().UTC().Add(-time.Minute)
+	applicants := make([]models.Applicant, maxTombstones+1)
+	for i := range applicants {
+		applicants[i] = models.Applicant{Name: "Ada Lovelace", Email: fmt.Sprintf("deleted%d@example.com", i),
+			Position: "Engineer", Status: utils.StatusPending, StatusChangedAt: time.Now().UTC()}
+	}
+	if err := database.DB.CreateInBatches(&applicants, 200).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := database.DB.Where("1 = 1").Delete(&models.Applicant{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	got := getChangesPage(t, newApplicantTestApp(), since, "limit=10")
+	if len(got.Deleted) != maxTombstones || !got.DeletedTruncated {
+		t.Errorf("%d tombstones (truncated %v), want %d and truncated", len(got.Deleted), got.DeletedTruncated, maxTombstones)
+	}
+}
+
+func TestGetApplicantsSurvivesCacheFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(server *miniredis.Miniredis)
+		operation string
+	}{
+		{"read error", func(*miniredis.Miniredis) { failRedisCommands("get") }, "read"},
+		{"write error", func(server *miniredis.Miniredis) {
+			server.FlushAll()
+			failRedisCommands("set")
+		}, "write"},
+		{"corrupt entry", func(server *miniredis.Miniredis) {
+			for _, key := range server.Keys() {
+				server.Set(key, "{not json")
+			}
+		}, "decode"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, server, _ := useTestBackends(t)
+			applicant := createTestApplicant(t, models.Applicant{})
+			app := newApplicantTestApp()
+			// Fill the cache first, so there is an entry to corrupt
+			doRequest(t, app, newJSONRequest(t, "GET", "/applicants", nil))
+			tc.setup(server)
+
+			errors := metrics.CacheErrors.WithLabelValues(tc.operation)
+			before := testutil.ToFloat64(errors)
+			resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/applicants", nil))
+			if resp.StatusCode != 200 {
+				t.Fatalf("status = %d, want 200, body %s", resp.StatusCode, body)
+			}
+			var page struct {
+				Data []models.Applicant `json:"data"`
+			}
+			decodeJSON(t, body, &page)
+			if len(page.Data) != 1 || page.Data[0].ID != applicant.ID {
+				t.Errorf("data = %+v, want applicant %d from the database", page.Data, applicant.ID)
+			}
+			if got := testutil.ToFloat64(errors) - before; got != 1 {
+				t.Errorf("%s cache errors rose by %v, want 1", tc.operation, got)
+			}
+		})
+	}
+}
+
+func TestGetApplicantsRedisDown(t *testing.T) {
+	_, server, _ := useTestBackends(t)
+	createTestApplicant(t, models.Applicant{})
+	server.Close()
+
+	reads, writes := metrics.CacheErrors.WithLabelValues("read"), metrics.CacheErrors.WithLabelValues("write")
+	readsBefore, writesBefore := testutil.ToFloat64(reads), testutil.ToFloat64(writes)
+	resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "GET", "/applicants", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200, body %s", resp.StatusCode, body)
+	}
+	if testutil.ToFloat64(reads) == readsBefore || testutil.ToFloat64(writes) == writesBefore {
+		t.Errorf("read and write cache errors were not both counted")
+	}
+}
+
+func TestGetApplicantSurvivesCacheFailures(t *testing.T) {
+	useTestBackends(t)
+	applicant := createTestApplicant(t, models.Applicant{})
+	failRedisCommands("get", "set")
+
+	resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "GET", fmt.Sprintf("/applicants/%d", applicant.ID), nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200, body %s", resp.StatusCode, body)
+	}
+	var got models.Applicant
+	decodeJSON(t, body, &got)
+	if got.ID != applicant.ID {
+		t.Errorf("id = %d, want %d", got.ID, applicant.ID)
+	}
+}
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want uint
+		ok   bool
+	}{
+		{"1", 1, true},
+		{"42", 42, true},
+		{"9223372036854775807", 9223372036854775807, true},
+		{"0", 0, false},
+		{"-1", 0, false},
+		{"+1", 0, false},
+		{"abc", 0, false},
+		{"1.5", 0, false},
+		{"1e3", 0, false},
+		{"9223372036854775808", 0, false},
+		{"18446744073709551616", 0, false},
+	}
+	app := fiber.New()
+	app.Get("/:id", func(c *fiber.Ctx) error {
+		id, ok := parseID(c)
+		return c.JSON(fiber.Map{"id": id, "ok": ok})
+	})
+	for _, tc := range tests {
+		resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/"+url.PathEscape(tc.raw), nil))
+		if resp.StatusCode != 200 {
+			t.Fatalf("%s: status = %d", tc.raw, resp.StatusCode)
+		}
+		var got struct {
+			ID uint `json:"id"`
+			OK bool `json:"ok"`
+		}
+		decodeJSON(t, body, &got)
+		if got.ID != tc.want || got.OK != tc.ok {
+			t.Errorf("parseID(%q) = %d, %v, want %d, %v", tc.raw, got.ID, got.OK, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestInvalidApplicantIDReturns400(t *testing.T) {
+	useTestBackends(t)
+	app := newApplicantTestApp()
+	for _, id := range []string{"0", "-1", "abc", "18446744073709551616"} {
+		for _, method := range []string{"GET", "PUT", "PATCH", "DELETE"} {
+			var body interface{}
+			if method == "PUT" || method == "PATCH" {
+				body = map[string]string{"notes": "x"}
+			}
+			req := newJSONRequest(t, method, "/applicants/"+id, body)
+			req.Header.Set(testRoleHeader, "admin")
+			resp, respBody := doRequest(t, app, req)
+			if resp.StatusCode != 400 {
+				t.Errorf("%s /applicants/%s: status = %d, want 400, body %s", method, id, resp.StatusCode, respBody)
+			}
+		}
+	}
+}
+
+func TestCleanStatus(t *testing.T) {
+	tests := map[utils.Status]utils.Status{
+		"pending":     "pending",
+		"Pending":     "pending",
+		"PENDING":     "pending",
+		" Reviewed  ": "reviewed",
+		"inTerViewed": "interviewed",
+		"":            "",
+	}
+	for raw, want := range tests {
+		if got := cleanStatus(raw); got != want {
+			t.Errorf("cleanStatus(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestStatusInputIgnoresCase(t *testing.T) {
+	useTestBackends(t)
+	app := newApplicantTestApp()
+
+	// storedStatus reads the status an applicant was saved with
+	storedStatus := func(id uint) utils.Status {
+		var applicant models.Applicant
+		if err := database.DB.First(&applicant, id).Error; err != nil {
+			t.Fatal(err)
+		}
+		return applicant.Status
+	}
+
+	resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants", map[string]string{
+		"name": "Ada Lovelace", "email": "ada@example.com", "position": "Engineer", "status": "PENDING",
+	}))
+	if resp.StatusCode != 201 {
+		t.Fatalf("create: status = %d, body %s", resp.StatusCode, body)
+	}
+	var created models.Applicant
+	decodeJSON(t, body, &created)
+	if got := storedStatus(created.ID); got != utils.StatusPending || created.Status != utils.StatusPending {
+		t.Errorf("create stored %q and returned %q, want pending", got, created.Status)
+	}
+
+	steps := []struct {
+		method, path string
+		body         map[string]string
+		want         utils.Status
+	}{
+		{"PUT", "", map[string]string{"status": "Reviewed"}, utils.StatusReviewed},
+		{"POST", "/transition", map[string]string{"to": " INTERVIEWED "}, utils.StatusInterviewed},
+		{"PATCH", "", map[string]string{"status": "rejected"}, utils.StatusRejected},
+	}
+	for _, step := range steps {
+		target := fmt.Sprintf("/applicants/%d%s", created.ID, step.path)
+		resp, body := doRequest(t, app, newJSONRequest(t, step.method, target, step.body))
+		if resp.StatusCode != 200 {
+			t.Fatalf("%s %s: status = %d, body %s", step.method, target, resp.StatusCode, body)
+		}
+		if got := storedStatus(created.ID); got != step.want {
+			t.Errorf("%s %s stored %q, want %q", step.method, target, got, step.want)
+		}
+	}
+
+	resp, body = doRequest(t, app, newJSONRequest(t, "GET", "/applicants?status=REJECTED", nil))
+	var page struct {
+		Data []models.Applicant `json:"data"`
+	}
+	decodeJSON(t, body, &page)
+	if resp.StatusCode != 200 || len(page.Data) != 1 {
+		t.Errorf("filter by REJECTED: status %d, %d applicants, want 1", resp.StatusCode, len(page.Data))
+	}
+}
+
+func TestStatusInputStillValidated(t *testing.T) {
+	useTestBackends(t)
+	app := newApplicantTestApp()
+	applicant := createTestApplicant(t, models.Applicant{})
+
+	resp, body := doRequest(t, app, newJSONRequest(t, "PUT", fmt.Sprintf("/applicants/%d", applicant.ID),
+		map[string]string{"status": "Pendingx"}))
+	if resp.StatusCode != 400 {
+		t.Errorf("unknown status: status = %d, want 400, body %s", resp.StatusCode, body)
+	}
+	// Casing doesn't get around the status flow
+	resp, body = doRequest(t, app, newJSONRequest(t, "PUT", fmt.Sprintf("/applicants/%d", applicant.ID),
+		map[string]string{"status": "HIRED"}))
+	if resp.StatusCode != 422 {
+		t.Errorf("pending to HIRED: status = %d, want 422, body %s", resp.StatusCode, body)
+	}
+}
+
+func TestUpdateResponseMatchesStoredApplicant(t *testing.T) {
+	for _, method := range []string{"PUT", "PATCH"} {
+		t.Run(method, func(t *testing.T) {
+			useTestBackends(t)
+			applicant := createTestApplicant(t, models.Applicant{Notes: "First call"})
+			old := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			if err := database.DB.Model(&applicant).UpdateColumn("updated_at", old).Error; err != nil {
+				t.Fatal(err)
+			}
+			app := newApplicantTestApp()
+			target := fmt.Sprintf("/applicants/%d", applicant.ID)
+			if resp, body := doRequest(t, app, newJSONRequest(t, "POST", target+"/tags", map[string][]string{"tags": {"referral"}})); resp.StatusCode != 200 {
+				t.Fatalf("tag: status = %d, body %s", resp.StatusCode, body)
+			}
+
+			// Values the handler normalizes before saving
+			resp, updated := doRequest(t, app, newJSONRequest(t, method, target, map[string]string{
+				"name": "  Ada King  ", "email": "ADA.KING@Example.com", "position": "Staff Engineer",
+			}))
+			if resp.StatusCode != 200 {
+				t.Fatalf("update: status = %d, body %s", resp.StatusCode, updated)
+			}
+			resp, fetched := doRequest(t, app, newJSONRequest(t, "GET", target, nil))
+			if resp.StatusCode != 200 {
+				t.Fatalf("get: status = %d, body %s", resp.StatusCode, fetched)
+			}
+
+			var got, want map[string]interface{}
+			decodeJSON(t, updated, &got)
+			decodeJSON(t, fetched, &want)
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("update response differs from GET:\n got %v\nwant %v", got, want)
+			}
+			if got["name"] != "Ada King" || got["email"] != "ada.king@example.com" || got["notes"] != "First call" {
+				t.Errorf("response = %v, want the normalized update with untouched fields kept", got)
+			}
+			if got["updated_at"] == old.Format(time.RFC3339) {
+				t.Errorf("updated_at = %v, want the time of the update", got["updated_at"])
+			}
+		})
+	}
+}
+
+func TestIncludeChanges(t *testing.T) {
+	useTestBackends(t)
+	applicant := createTestApplicant(t, models.Applicant{Position: "Engineer", Notes: "First call", PortfolioURL: "https://example.com/ada"})
+	app := newApplicantTestApp()
+	target := fmt.Sprintf("/applicants/%d", applicant.ID)
+
+	type response struct {
+		Name    string                        `json:"name"`
+		Changes map[string]models.FieldChange `json:"changes"`
+	}
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   map[string]interface{}
+		status int
+		want   map[string]models.FieldChange
+	}{
+		{"partial PUT", "PUT", target + "?include_changes=true", map[string]interface{}{
+			"position": "Staff Engineer", "notes": "First call", "name": applicant.Name,
+		}, 200, map[string]models.FieldChange{
+			"position": {Old: "Engineer", New: "Staff Engineer"},
+		}},
+		{"PATCH clearing a field", "PATCH", target + "?include_changes=1", map[string]interface{}{
+			"portfolio_url": nil, "status": "reviewed",
+		}, 200, map[string]models.FieldChange{
+			"portfolio_url": {Old: "https://example.com/ada", New: ""},
+			"status":        {Old: "pending", New: "reviewed"},
+		}},
+		{"no-op PUT", "PUT", target + "?include_changes=true", map[string]interface{}{
+			"position": "Staff Engineer",
+		}, 200, map[string]models.FieldChange{}},
+		{"create", "POST", "/applicants?include_changes=true", map[string]interface{}{
+			"name": "Grace Hopper", "email": "grace@example.com", "position": "Admiral",
+		}, 201, map[string]models.FieldChange{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, body := doRequest(t, app, newJSONRequest(t, tc.method, tc.target, tc.body))
+			if resp.StatusCode != tc.status {
+				t.Fatalf("status = %d, want %d, body %s", resp.StatusCode, tc.status, body)
+			}
+			var got response
+			decodeJSON(t, body, &got)
+			if got.Name == "" || got.Changes == nil || fmt.Sprint(got.Changes) != fmt.Sprint(tc.want) {
+				t.Errorf("response = %s, want the applicant with changes %v", body, tc.want)
+			}
+		})
+	}
+
+	// Without the option the body is the plain applicant
+	_, body := doRequest(t, app, newJSONRequest(t, "PUT", target, map[string]string{"notes": "Second call"}))
+	var plain map[string]interface{}
+	decodeJSON(t, body, &plain)
+	if _, ok := plain["changes"]; ok {
+		t.Errorf("response without include_changes has changes: %s", body)
+	}
+}
+
+// useImmutableEmail allows every updatable field except email
+func useImmutableEmail(t *testing.T) {
+	t.Helper()
+	cfg := defaultApplicantConfig()
+	for field := range ApplicantUpdateSchema {
+		if field != "email" {
+			cfg.UpdatableFields = append(cfg.UpdatableFields, field)
+		}
+	}
+	useApplicantConfig(t, cfg)
+}
+
+func TestImmutableEmailRejectsEmailChange(t *testing.T) {
+	for _, method := range []string{"PUT", "PATCH"} {
+		t.Run(method, func(t *testing.T) {
+			useTestBackends(t)
+			useImmutableEmail(t)
+			applicant := createTestApplicant(t, models.Applicant{Name: "Ada", Email: "ada@example.com"})
+
+			body := map[string]interface{}{"name": "Ada Lovelace", "email": "ada.l@example.com"}
+			req := newJSONRequest(t, method, fmt.Sprintf("/applicants/%d", applicant.ID), body)
+			resp, respBody := doRequest(t, newApplicantTestApp(), req)
+			if resp.StatusCode != 422 {
+				t.Fatalf("status = %d, want 422: %s", resp.StatusCode, respBody)
+			}
+			var got struct {
+				Error string `json:"error"`
+				Field string `json:"field"`
+			}
+			decodeJSON(t, respBody, &got)
+			if got.Field != "email" || got.Error != "email may not be updated" {
+				t.Errorf("response = %+v, want email named as the disallowed field", got)
+			}
+
+			var stored models.Applicant
+			if err := database.DB.First(&stored, applicant.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			if stored.Email != "ada@example.com" || stored.Name != "Ada" {
+				t.Errorf("stored applicant = %q <%s>, want it unchanged", stored.Name, stored.Email)
+			}
+		})
+	}
+}
+
+func TestImmutableEmailAllowsOtherFields(t *testing.T) {
+	for _, method := range []string{"PUT", "PATCH"} {
+		t.Run(method, func(t *testing.T) {
+			useTestBackends(t)
+			useImmutableEmail(t)
+			applicant := createTestApplicant(t, models.Applicant{Name: "Ada", Email: "ada@example.com"})
+
+			body := map[string]interface{}{"name": "Ada Lovelace", "position": "Analyst"}
+			req := newJSONRequest(t, method, fmt.Sprintf("/applicants/%d", applicant.ID), body)
+			resp, respBody := doRequest(t, newApplicantTestApp(), req)
+			if resp.StatusCode != 200 {
+				t.Fatalf("status = %d, want 200: %s", resp.StatusCode, respBody)
+			}
+			var got models.Applicant
+			decodeJSON(t, respBody, &got)
+			if got.Name != "Ada Lovelace" || got.Position != "Analyst" || got.Email != "ada@example.com" {
+				t.Errorf("updated applicant = %q, %q <%s>", got.Name, got.Position, got.Email)
+			}
+		})
+	}
+}
+
+func TestEmailUpdatableByDefault(t *testing.T) {
+	useTestBackends(t)
+	applicant := createTestApplicant(t, models.Applicant{Email: "ada@example.com"})
+
+	req := newJSONRequest(t, "PATCH", fmt.Sprintf("/applicants/%d", applicant.ID), map[string]interface{}{"email": "ada.l@example.com"})
+	resp, body := doRequest(t, newApplicantTestApp(), req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+	}
+	var got models.Applicant
+	decodeJSON(t, body, &got)
+	if got.Email != "ada.l@example.com" {
+		t.Errorf("email = %q, want ada.l@example.com", got.Email)
+	}
+}
+
+func TestInvalidPositionReturns422(t *testing.T) {
+	useTestBackends(t)
+	applicant := createTestApplicant(t, models.Applicant{Position: "Engineer"})
+	app := newApplicantTestApp()
+
+	for _, position := range []string{"Engineer.", "X", "Senior\nEngineer", "-Designer"} {
+		body := map[string]interface{}{"name": "Ada", "email": "ada@example.com", "position": position}
+		resp, respBody := doRequest(t, app, newJSONRequest(t, "POST", "/applicants", body))
+		var created struct {
+			Errors []utils.FieldError `json:"errors"`
+		}
+		decodeJSON(t, respBody, &created)
+		if resp.StatusCode != 422 || len(created.Errors) != 1 || created.Errors[0].Field != "position" {
+			t.Errorf("POST position %q: status %d, body %s, want 422 for position", position, resp.StatusCode, respBody)
+		}
+
+		for _, method := range []string{"PUT", "PATCH"} {
+			req := newJSONRequest(t, method, fmt.Sprintf("/applicants/%d", applicant.ID), map[string]interface{}{"position": position})
+			resp, respBody := doRequest(t, app, req)
+			var updated struct {
+				Field string `json:"field"`
+			}
+			decodeJSON(t, respBody, &updated)
+			if resp.StatusCode != 422 || updated.Field != "position" {
+				t.Errorf("%s position %q: status %d, body %s, want 422 for position", method, position, resp.StatusCode, respBody)
+			}
+		}
+	}
+
+	var stored models.Applicant
+	if err := database.DB.First(&stored, applicant.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Position != "Engineer" {
+		t.Errorf("position = %q after rejected updates, want Engineer", stored.Position)
+	}
+	var count int64
+	database.DB.Model(&models.Applicant{}).Count(&count)
+	if count != 1 {
+		t.Errorf("%d applicants, want the rejected creates to add none", count)
+	}
+}
+
+func TestTextAndLinkErrorsReportEveryField(t *testing.T) {
+	useTestBackends(t)
+	cfg := defaultApplicantConfig()
+	cfg.MaxNotesLength = 10
+	cfg.MaxResumeLength = 10
+	useApplicantConfig(t, cfg)
+	applicant := createTestApplicant(t, models.Applicant{})
+	app := newApplicantTestApp()
+
+	invalid := map[string]interface{}{
+		"notes":         strings.Repeat("n", 11),
+		"resume":        strings.Repeat("r", 11),
+		"linkedin_url":  "ftp://example.com/ada",
+		"portfolio_url": "example.com",
+	}
+	wantFields := []string{"linkedin_url", "notes", "portfolio_url", "resume"}
+	fieldsOf := func(errs []utils.FieldError) []string {
+		fields := make([]string, len(errs))
+		for i, fieldErr := range errs {
+			fields[i] = fieldErr.Field
+		}
+		sort.Strings(fields)
+		return fields
+	}
+
+	create := map[string]interface{}{"name": "Grace Hopper", "email": "grace@example.com", "position": "Engineer"}
+	for field, value := range invalid {
+		create[field] = value
+	}
+	resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants", create))
+	var created struct {
+		Errors []utils.FieldError `json:"errors"`
+	}
+	decodeJSON(t, body, &created)
+	if resp.StatusCode != 422 || fmt.Sprint(fieldsOf(created.Errors)) != fmt.Sprint(wantFields) {
+		t.Errorf("POST: status %d, body %s, want 422 for %v", resp.StatusCode, body, wantFields)
+	}
+
+	// Updates report the links and the lengths as separate checks
+	for _, method := range []string{"PUT", "PATCH"} {
+		for _, fields := range [][]string{{"notes", "resume"}, {"linkedin_url", "portfolio_url"}} {
+			update := map[string]interface{}{}
+			for _, field := range fields {
+				update[field] = invalid[field]
+			}
+			resp, body := doRequest(t, app, newJSONRequest(t, method, fmt.Sprintf("/applicants/%d", applicant.ID), update))
+			var updated struct {
+				Field  string             `json:"field"`
+				Errors []utils.FieldError `json:"errors"`
+			}
+			decodeJSON(t, body, &updated)
+			if resp.StatusCode != 422 || updated.Field != fields[0] || fmt.Sprint(fieldsOf(updated.Errors)) != fmt.Sprint(fields) {
+				t.Errorf("%s %v: status %d, body %s, want 422 for each field", method, fields, resp.StatusCode, body)
+			}
+		}
+	}
+}
+
+func TestUpdateEmailToAnotherApplicantsReturns409(t *testing.T) {
+	for _, method := range []string{"PUT", "PATCH"} {
+		t.Run(method, func(t *testing.T) {
+			useTestBackends(t)
+			cfg := defaultApplicantConfig()
+			cfg.EmailNormalizeProviders = []string{"gmail.com"}
+			useApplicantConfig(t, cfg)
+			applicant := createTestApplicant(t, models.Applicant{Email: "ada@example.com"})
+			createTestApplicant(t, models.Applicant{Email: "grace@example.com"})
+			createTestApplicant(t, models.Applicant{Email: "alan.turing@gmail.com", CanonicalEmail: "alanturing@gmail.com"})
+			deleted := createTestApplicant(t, models.Applicant{Email: "deleted@example.com"})
+			if err := database.DB.Delete(&deleted).Error; err != nil {
+				t.Fatal(err)
+			}
+			app := newApplicantTestApp()
+			target := fmt.Sprintf("/applicants/%d", applicant.ID)
+
+			for _, email := range []string{"grace@example.com", " Grace@Example.com ", "alanturing+jobs@gmail.com", "deleted@example.com"} {
+				resp, body := doRequest(t, app, newJSONRequest(t, method, target, map[string]interface{}{"email": email}))
+				var got struct {
+					Error string `json:"error"`
+				}
+				decodeJSON(t, body, &got)
+				if resp.StatusCode != 409 || got.Error != "Email already exists" {
+					t.Errorf("email %q: status %d, body %s, want 409 Email already exists", email, resp.StatusCode, body)
+				}
+			}
+
+			var stored models.Applicant
+			if err := database.DB.First(&stored, applicant.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			if stored.Email != "ada@example.com" {
+				t.Errorf("email = %q after rejected updates, want ada@example.com", stored.Email)
+			}
+
+			// Sending the applicant's own email, in any case, is not a conflict
+			resp, body := doRequest(t, app, newJSONRequest(t, method, target, map[string]interface{}{"email": "ADA@example.com"}))
+			if resp.StatusCode != 200 {
+				t.Errorf("own email: status %d, body %s, want 200", resp.StatusCode, body)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"encoding/json"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// currentUser returns the authenticated user id from the request, if any
+func currentUser(c *fiber.Ctx) string {
+	if userID, ok := c.Locals("user_id").(string); ok {
+		return userID
+	}
+	return ""
+}
+
+// recordAudit writes an audit entry for an applicant using the given connection,
+// so it can take part in the caller's transaction
+func recordAudit(tx *gorm.DB, applicantID uint, action, actor string, changes map[string]models.FieldChange, reason string) error {
+	entry := models.AuditLog{
+		ApplicantID: applicantID,
+		Action:      action,
+		Actor:       actor,
+		Reason:      reason,
+	}
+	if len(changes) > 0 {
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return err
+		}
+		entry.Changes = data
+	}
+	return tx.Create(&entry).Error
+}
+
+// recordStatusChange adds a status move to the applicant's status history,
+// using the given connection so it is saved with the change itself
+func recordStatusChange(tx *gorm.DB, applicantID uint, from, to utils.Status, actor string) error {
+	return tx.Create(&models.StatusHistory{
+		ApplicantID: applicantID,
+		FromStatus:  from,
+		ToStatus:    to,
+		ChangedBy:   actor,
+		ChangedAt:   time.Now().UTC(),
+	}).Error
+}
@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// auditExportColumns is the CSV header of an audit export
+var auditExportColumns = []string{"id", "created_at", "applicant_id", "action", "actor", "reason", "changes"}
+
+// ExportApplicantAudit streams the audit trail of one applicant. Deleted
+// applicants keep their trail, so they can be exported too.
+func ExportApplicantAudit(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+	var applicant models.Applicant
+	if err := database.DB.Unscoped().Select("id").First(&applicant, id).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+	}
+	return exportAudit(c, database.DB.Where("applicant_id = ?", id), fmt.Sprintf("applicant-%d-audit", id))
+}
+
+// ExportAuditLog streams the audit trail of every applicant
+func ExportAuditLog(c *fiber.Ctx) error {
+	return exportAudit(c, database.DB, "audit")
+}
+
+// exportAudit streams the audit entries matching the action and since/until
+// filters as CSV or, with format=json, a JSON array. Entries are read in
+// keyset chunks in id order, like the applicant export.
+func exportAudit(c *fiber.Ctx, query *gorm.DB, filename string) error {
+	format := c.Query("format", "csv")
+	if format != "csv" && format != "json" {
+		return c.Status(400).JSON(fiber.Map{"error": "format must be csv or json"})
+	}
+
+	query, err := timeRange(c, query.Model(&models.AuditLog{}))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+
+	if format == "json" {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	} else {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	}
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		var writeEntry func(entry *models.AuditLog) error
+		var csvWriter *csv.Writer
+		if format == "json" {
+			w.WriteString("[")
+			first := true
+			writeEntry = func(entry *models.AuditLog) error {
+				data, err := json.Marshal(entry)
+				if err != nil {
+					return err
+				}
+				if !first {
+					w.WriteString(",")
+				}
+				first = false
+				_, err = w.Write(data)
+				return err
+			}
+		} else {
+			csvWriter = csv.NewWriter(w)
+			csvWriter.Write(auditExportColumns)
+			writeEntry = func(entry *models.AuditLog) error {
+				return csvWriter.Write([]string{
+					strconv.FormatUint(uint64(entry.ID), 10),
+					entry.CreatedAt.Format(time.RFC3339),
+					strconv.FormatUint(uint64(entry.ApplicantID), 10),
+					entry.Action,
+					entry.Actor,
+					entry.Reason,
+					string(entry.Changes),
+				})
+			}
+		}
+
+		count := 0
+		var lastID uint
+		for {
+			var chunk []models.AuditLog
+			if err := query.Session(&gorm.Session{}).
+				Where("id > ?", lastID).
+				Order("id ASC").
+				Limit(exportChunkSize).
+				Find(&chunk).Error; err != nil {
+				log.Printf("Database error during audit export after id %d: %v", lastID, err)
+				break
+			}
+
+			for i := range chunk {
+				if err := writeEntry(&chunk[i]); err != nil {
+					log.Printf("Audit export aborted after %d entries: %v", count, err)
+					return
+				}
+			}
+			count += len(chunk)
+
+			// Hand each chunk to the client before reading the next one
+			if csvWriter != nil {
+				csvWriter.Flush()
+			}
+			if err := w.Flush(); err != nil {
+				log.Printf("Audit export aborted after %d entries: %v", count, err)
+				return
+			}
+
+			if len(chunk) < exportChunkSize {
+				break
+			}
+			lastID = chunk[len(chunk)-1].ID
+		}
+
+		if format == "json" {
+			w.WriteString("]")
+		}
+		log.Printf("Exported %d audit entries", count)
+	})
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"errors"
+	"job-tracker/auth"
+	"job-tracker/authaudit"
+	"job-tracker/config"
+	"job-tracker/database"
+	"job-tracker/models"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// LoginRequest is the body accepted by Login
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// dummyHash is compared against when the email is unknown, so a failed login
+// takes as long whether or not the account exists
+var (
+	dummyHash     []byte
+	dummyHashOnce sync.Once
+)
+
+func compareDummyHash(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcrypt.DefaultCost)
+	})
+	bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
+
+// Login checks an email and password and returns a signed JWT carrying the
+// user's id and role. Any failure returns the same 401, so the response never
+// reveals whether the email has an account.
+func Login(c *fiber.Ctx) error {
+	var req LoginRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+
+	var user models.User
+	err := database.DB.Where("email = ?", email).First(&user).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		compareDummyHash(req.Password)
+		return loginFailed(c, email, "unknown email")
+	case err != nil:
+		log.Printf("Database error looking up user: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to log in"})
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return loginFailed(c, email, "wrong password")
+	}
+
+	userID := strconv.FormatUint(uint64(user.ID), 10)
+	token, expiresAt, err := auth.Issue(userID, user.Role)
+	if err != nil {
+		log.Printf("Failed to sign token for user %s: %v", userID, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to log in"})
+	}
+	authaudit.Record(c, authaudit.Login, userID, true, "")
+
+	return c.JSON(fiber.Map{
+		"token":      token,
+		"token_type": "Bearer",
+		"expires_at": expiresAt,
+	})
+}
+
+// loginFailed records a failed login and returns the generic 401. The reason
+// is only kept in the auth event log.
+func loginFailed(c *fiber.Ctx, email, reason string) error {
+	authaudit.Record(c, authaudit.LoginFailed, email, false, reason)
+	return c.Status(401).JSON(fiber.Map{"error": "Invalid email or password"})
+}
+
+// SeedAdminUser creates the ADMIN_EMAIL account with the admin role at startup
+// if no user has that email yet. An existing account is left untouched.
+func SeedAdminUser(cfg config.AuthConfig) {
+	if cfg.AdminEmail == "" {
+		return
+	}
+	var count int64
+	if err := database.DB.Model(&models.User{}).Where("email = ?", cfg.AdminEmail).Count(&count).Error; err != nil {
+		log.Printf("Warning: could not check for the admin user: %v", err)
+		return
+	}
+	if count > 0 {
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(cfg.AdminPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Printf("Warning: could not hash ADMIN_PASSWORD: %v", err)
+		return
+	}
+	user := models.User{Email: cfg.AdminEmail, PasswordHash: string(hash), Role: "admin"}
+	if err := database.DB.Create(&user).Error; err != nil {
+		log.Printf("Warning: could not create the admin user: %v", err)
+		return
+	}
+	log.Printf("Created admin user %s", cfg.AdminEmail)
+}
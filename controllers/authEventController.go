@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/authaudit"
+	"job-tracker/database"
+	"job-tracker/models"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListAuthEvents returns authentication events, newest first. They can be
+// filtered by type, identity, ip, success and a since/until time range
+// (RFC3339), and are paginated with page and limit.
+func ListAuthEvents(c *fiber.Ctx) error {
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	query := database.DB.Model(&models.AuthEvent{})
+	if eventType := c.Query("type"); eventType != "" {
+		if !isAuthEventType(eventType) {
+			return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("unknown event type %q", eventType)})
+		}
+		query = query.Where("type = ?", eventType)
+	}
+	if identity := c.Query("identity"); identity != "" {
+		query = query.Where("identity = ?", identity)
+	}
+	if ip := c.Query("ip"); ip != "" {
+		query = query.Where("ip = ?", ip)
+	}
+	if raw := c.Query("success"); raw != "" {
+		success, err := strconv.ParseBool(raw)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "success must be true or false"})
+		}
+		query = query.Where("success = ?", success)
+	}
+	if query, err = timeRange(c, query); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Printf("Database error counting auth events: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch auth events"})
+	}
+
+	events := []models.AuthEvent{}
+	if err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&events).Error; err != nil {
+		log.Printf("Database error fetching auth events: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch auth events"})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":  events,
+		"page":  page,
+		"limit": limit,
+		"total": total,
+	})
+}
+
+func isAuthEventType(eventType string) bool {
+	for _, known := range authaudit.Types {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/events"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BatchIDsRequest is a request body carrying a list of applicant ids
+type BatchIDsRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+// batchResult is the outcome of a batch operation for a single id
+type batchResult struct {
+	ID     int64  `json:"id"`
+	Result string `json:"result"`
+}
+
+// parseBatchIDs reads the id list from the body, enforcing the batch size cap
+func parseBatchIDs(c *fiber.Ctx) ([]int64, error) {
+	var req BatchIDsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, fmt.Errorf("Invalid request body")
+	}
+	if len(req.IDs) == 0 {
+		return nil, fmt.Errorf("ids must contain at least one id")
+	}
+	if len(req.IDs) > maxBatchSize {
+		return nil, fmt.Errorf("ids must contain at most %d ids", maxBatchSize)
+	}
+	return req.IDs, nil
+}
+
+// DeleteApplicantsBatch soft-deletes several applicants in the request
+// transaction and reports for every id whether it was deleted, not found or
+// invalid
+func DeleteApplicantsBatch(c *fiber.Ctx) error {
+	ids, err := parseBatchIDs(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Drop duplicates and non-positive ids, keeping the input order for the results
+	seen := make(map[int64]bool, len(ids))
+	var lookup []int64
+	for _, id := range ids {
+		if id > 0 && !seen[id] {
+			lookup = append(lookup, id)
+		}
+		seen[id] = true
+	}
+
+	db := middleware.DB(c)
+	actor := currentUser(c)
+	var existing []models.Applicant
+	if len(lookup) > 0 {
+		if err := db.Where("id IN ?", lookup).Find(&existing).Error; err != nil {
+			log.Printf("[%s] Database error loading applicants to batch delete: %v", middleware.RequestID(c), err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to delete applicants"})
+		}
+	}
+	deleted := make(map[int64]bool, len(existing))
+	if len(existing) > 0 {
+		if err := db.Delete(&existing).Error; err != nil {
+			log.Printf("[%s] Database error batch deleting applicants: %v", middleware.RequestID(c), err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to delete applicants"})
+		}
+	}
+	for _, applicant := range existing {
+		if err := recordAudit(db, applicant.ID, "delete", actor, nil, "batch delete"); err != nil {
+			log.Printf("[%s] Database error auditing batch delete of applicant %d: %v", middleware.RequestID(c), applicant.ID, err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to delete applicants"})
+		}
+		deleted[int64(applicant.ID)] = true
+	}
+
+	results := make([]batchResult, 0, len(ids))
+	reported := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if reported[id] {
+			continue
+		}
+		reported[id] = true
+
+		switch {
+		case id <= 0:
+			results = append(results, batchResult{ID: id, Result: "invalid"})
+		case deleted[id]:
+			results = append(results, batchResult{ID: id, Result: "deleted"})
+		default:
+			results = append(results, batchResult{ID: id, Result: "not_found"})
+		}
+	}
+
+	// Clear cache once for the whole batch, after the deletes are saved
+	middleware.AfterCommit(c, func() {
+		if len(existing) > 0 {
+			invalidateApplicantListCache()
+		}
+		for _, applicant := range existing {
+			invalidateApplicantCache(applicant.ID)
+			releaseReviewLock(applicant.ID)
+			publishEvent(events.ApplicantDeleted, applicant, actor, nil)
+		}
+	})
+	log.Printf("Batch deleted %d of %d applicants", len(deleted), len(results))
+
+	return c.JSON(fiber.Map{
+		"deleted": len(deleted),
+		"results": results,
+	})
+}
+
+// FetchApplicantsBatch returns the applicants with the given ids, paginated
+// with the same page/limit parameters and envelope as the list endpoint. The
+// matches are ordered by id, or with order=input in the order the ids were
+// sent. Ids that match no applicant are listed in not_found on every page.
+func FetchApplicantsBatch(c *fiber.Ctx) error {
+	ids, err := parseBatchIDs(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	pageInt, limitInt, err := parsePagination(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	order := c.Query("order", "id")
+	if order != "id" && order != "input" {
+		return c.Status(400).JSON(fiber.Map{"error": "order must be id or input"})
+	}
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Drop duplicates and non-positive ids, keeping the input order
+	seen := make(map[int64]bool, len(ids))
+	var lookup []int64
+	for _, id := range ids {
+		if id > 0 && !seen[id] {
+			lookup = append(lookup, id)
+		}
+		seen[id] = true
+	}
+
+	// Find which ids exist first, so pages are cut from the matches only
+	var matchedIDs []int64
+	if len(lookup) > 0 {
+		if err := applicantQuery(applicantFilters{}).Where("id IN ?", lookup).Order("id ASC").Pluck("id", &matchedIDs).Error; err != nil {
+			log.Printf("Database error batch fetching applicants: %v", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicants"})
+		}
+	}
+	matched := make(map[int64]bool, len(matchedIDs))
+	for _, id := range matchedIDs {
+		matched[id] = true
+	}
+
+	ordered := matchedIDs
+	notFound := []int64{}
+	if order == "input" {
+		ordered = make([]int64, 0, len(matchedIDs))
+	}
+	for _, id := range lookup {
+		switch {
+		case !matched[id]:
+			notFound = append(notFound, id)
+		case order == "input":
+			ordered = append(ordered, id)
+		}
+	}
+
+	applicants := []models.Applicant{}
+	offset := (pageInt - 1) * limitInt
+	if offset < len(ordered) {
+		pageIDs := ordered[offset:min(offset+limitInt, len(ordered))]
+		var rows []models.Applicant
+		if err := applicantQuery(applicantFilters{}).Scopes(withTags).Where("id IN ?", pageIDs).Find(&rows).Error; err != nil {
+			log.Printf("Database error batch fetching applicants: %v", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicants"})
+		}
+
+		byID := make(map[int64]models.Applicant, len(rows))
+		for _, applicant := range rows {
+			byID[int64(applicant.ID)] = applicant
+		}
+		for _, id := range pageIDs {
+			// A row deleted between the two queries is simply skipped
+			if applicant, ok := byID[id]; ok {
+				applicants = append(applicants, applicant)
+			}
+		}
+	}
+
+	localizeApplicants(applicants, loc)
+	return c.JSON(fiber.Map{
+		"data":      applicants,
+		"page":      pageInt,
+		"limit":     limitInt,
+		"total":     len(ordered),
+		"not_found": notFound,
+	})
+}
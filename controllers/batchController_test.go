@@ -0,0 +1,248 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/events"
+	"job-tracker/models"
+	"testing"
+)
+
+func TestDeleteApplicantsBatchMixedIDs(t *testing.T) {
+	_, server, queue := useTestBackends(t)
+	published := useTestEvents(t, queue)
+	first := createTestApplicant(t, models.Applicant{})
+	second := createTestApplicant(t, models.Applicant{})
+	kept := createTestApplicant(t, models.Applicant{})
+	gone := createTestApplicant(t, models.Applicant{})
+	if err := database.DB.Delete(&gone).Error; err != nil {
+		t.Fatal(err)
+	}
+	server.Set(applicantCacheKey(first.ID), "x")
+	server.Set(applicantCacheKey(kept.ID), "x")
+	server.Set("applicants_page_1_limit_10_sort_created_at_desc", "x")
+	server.Set(reviewLockKey(first.ID), "reviewer@example.com")
+	server.Set(reviewLockKey(kept.ID), "reviewer@example.com")
+
+	ids := []int64{int64(first.ID), 999999, int64(second.ID), -1, 0, int64(first.ID), int64(gone.ID)}
+	req := newJSONRequest(t, "DELETE", "/applicants/batch", map[string][]int64{"ids": ids})
+	req.Header.Set(testRoleHeader, "admin")
+	resp, body := doRequest(t, newApplicantTestApp(), req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Deleted int           `json:"deleted"`
+		Results []batchResult `json:"results"`
+	}
+	decodeJSON(t, body, &result)
+	want := []batchResult{
+		{ID: int64(first.ID), Result: "deleted"},
+		{ID: 999999, Result: "not_found"},
+		{ID: int64(second.ID), Result: "deleted"},
+		{ID: -1, Result: "invalid"},
+		{ID: 0, Result: "invalid"},
+		{ID: int64(gone.ID), Result: "not_found"},
+	}
+	if result.Deleted != 2 || fmt.Sprint(result.Results) != fmt.Sprint(want) {
+		t.Errorf("response = %+v, want 2 deleted and results %+v", result, want)
+	}
+
+	var remaining []uint
+	database.DB.Model(&models.Applicant{}).Order("id").Pluck("id", &remaining)
+	if fmt.Sprint(remaining) != fmt.Sprint([]uint{kept.ID}) {
+		t.Errorf("remaining applicants = %v, want only %d", remaining, kept.ID)
+	}
+	var softDeleted int64
+	database.DB.Unscoped().Model(&models.Applicant{}).Where("id IN ? AND deleted_at IS NOT NULL", []uint{first.ID, second.ID}).Count(&softDeleted)
+	if softDeleted != 2 {
+		t.Errorf("%d rows soft-deleted, want 2 kept with deleted_at set", softDeleted)
+	}
+
+	for _, applicant := range []models.Applicant{first, second} {
+		var audit models.AuditLog
+		if err := database.DB.Where("applicant_id = ? AND action = ?", applicant.ID, "delete").First(&audit).Error; err != nil {
+			t.Errorf("applicant %d: no delete audit: %v", applicant.ID, err)
+		} else if audit.Actor != "recruiter@example.com" || audit.Reason != "batch delete" {
+			t.Errorf("applicant %d: audit = %+v", applicant.ID, audit)
+		}
+	}
+
+	if server.Exists(applicantCacheKey(first.ID)) || server.Exists("applicants_page_1_limit_10_sort_created_at_desc") {
+		t.Errorf("cache still holds the deleted applicant or list page: %v", server.Keys())
+	}
+	if !server.Exists(applicantCacheKey(kept.ID)) {
+		t.Errorf("cached applicant %d was dropped", kept.ID)
+	}
+	if server.Exists(reviewLockKey(first.ID)) || !server.Exists(reviewLockKey(kept.ID)) {
+		t.Errorf("review locks = %v, want only the deleted applicant's released", server.Keys())
+	}
+
+	if len(*published) != 2 {
+		t.Fatalf("published %v, want a delete event per deleted applicant", eventTypes(*published))
+	}
+	gotIDs := map[uint]bool{}
+	for _, event := range *published {
+		if event.Type != events.ApplicantDeleted || event.Actor != "recruiter@example.com" {
+			t.Errorf("event = %+v, want applicant.deleted by the caller", event)
+		}
+		gotIDs[event.ApplicantID] = true
+	}
+	if !gotIDs[first.ID] || !gotIDs[second.ID] {
+		t.Errorf("delete events for %v, want %d and %d", gotIDs, first.ID, second.ID)
+	}
+}
+
+func TestDeleteApplicantsBatchLimits(t *testing.T) {
+	useTestBackends(t)
+	cfg := defaultApplicantConfig()
+	cfg.MaxBatchSize = 2
+	useApplicantConfig(t, cfg)
+	applicant := createTestApplicant(t, models.Applicant{})
+	app := newApplicantTestApp()
+
+	tests := []struct {
+		name   string
+		role   string
+		ids    []int64
+		status int
+	}{
+		{"recruiter", "recruiter", []int64{int64(applicant.ID)}, 403},
+		{"empty", "admin", []int64{}, 400},
+		{"over the cap", "admin", []int64{1, 2, 3}, 400},
+	}
+	for _, tc := range tests {
+		req := newJSONRequest(t, "DELETE", "/applicants/batch", map[string][]int64{"ids": tc.ids})
+		req.Header.Set(testRoleHeader, tc.role)
+		if resp, body := doRequest(t, app, req); resp.StatusCode != tc.status {
+			t.Errorf("%s: status = %d, want %d, body %s", tc.name, resp.StatusCode, tc.status, body)
+		}
+	}
+	var count int64
+	database.DB.Model(&models.Applicant{}).Count(&count)
+	if count != 1 {
+		t.Errorf("%d applicants left, want the rejected batches to delete nothing", count)
+	}
+}
+
+func TestFetchApplicantsBatchPages(t *testing.T) {
+	useTestBackends(t)
+	var ids []int64
+	for i := 0; i < 40; i++ {
+		ids = append(ids, int64(createTestApplicant(t, models.Applicant{}).ID))
+	}
+	deleted := createTestApplicant(t, models.Applicant{})
+	if err := database.DB.Delete(&deleted).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	// Send the ids interleaved from both ends, with a repeat and some misses
+	var input, byInput []int64
+	for i, j := 0, len(ids)-1; i <= j; i, j = i+1, j-1 {
+		input = append(input, ids[j])
+		byInput = append(byInput, ids[j])
+		if i != j {
+			input = append(input, ids[i])
+			byInput = append(byInput, ids[i])
+		}
+	}
+	input = append(input, ids[0], 0, int64(deleted.ID), 9999)
+	wantNotFound := fmt.Sprint([]int64{int64(deleted.ID), 9999})
+	app := newApplicantTestApp()
+
+	for _, order := range []string{"id", "input"} {
+		t.Run(order, func(t *testing.T) {
+			want := ids
+			if order == "input" {
+				want = byInput
+			}
+			var got []int64
+			for page := 1; page <= 7; page++ {
+				target := fmt.Sprintf("/applicants/batch?order=%s&limit=7&page=%d", order, page)
+				resp, body := doRequest(t, app, newJSONRequest(t, "POST", target, map[string][]int64{"ids": input}))
+				if resp.StatusCode != 200 {
+					t.Fatalf("page %d: status = %d, body %s", page, resp.StatusCode, body)
+				}
+				var result struct {
+					Data     []models.Applicant `json:"data"`
+					Page     int                `json:"page"`
+					Limit    int                `json:"limit"`
+					Total    int                `json:"total"`
+					NotFound []int64            `json:"not_found"`
+				}
+				decodeJSON(t, body, &result)
+				if result.Page != page || result.Limit != 7 || result.Total != len(ids) {
+					t.Errorf("page %d: page %d, limit %d, total %d, want total %d", page, result.Page, result.Limit, result.Total, len(ids))
+				}
+				if fmt.Sprint(result.NotFound) != wantNotFound {
+					t.Errorf("page %d: not_found = %v, want %s", page, result.NotFound, wantNotFound)
+				}
+				wantLen := min(7, max(0, len(ids)-(page-1)*7))
+				if len(result.Data) != wantLen {
+					t.Errorf("page %d: %d applicants, want %d", page, len(result.Data), wantLen)
+				}
+				for _, applicant := range result.Data {
+					got = append(got, int64(applicant.ID))
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("pages joined = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFetchApplicantsBatchRejectsInvalidRequests(t *testing.T) {
+	useTestBackends(t)
+	cfg := defaultApplicantConfig()
+	cfg.MaxBatchSize = 3
+	useApplicantConfig(t, cfg)
+	app := newApplicantTestApp()
+
+	tests := []struct {
+		name  string
+		query string
+		ids   []int64
+	}{
+		{"over the cap", "", []int64{1, 2, 3, 4}},
+		{"empty", "", []int64{}},
+		{"unknown order", "?order=name", []int64{1}},
+		{"zero limit", "?limit=0", []int64{1}},
+		{"zero page", "?page=0", []int64{1}},
+	}
+	for _, tc := range tests {
+		req := newJSONRequest(t, "POST", "/applicants/batch"+tc.query, map[string][]int64{"ids": tc.ids})
+		if resp, body := doRequest(t, app, req); resp.StatusCode != 400 {
+			t.Errorf("%s: status = %d, want 400, body %s", tc.name, resp.StatusCode, body)
+		}
+	}
+}
+
+func TestDeleteApplicantsBatchRollsBackOnFailure(t *testing.T) {
+	_, server, queue := useTestBackends(t)
+	published := useTestEvents(t, queue)
+	first := createTestApplicant(t, models.Applicant{})
+	second := createTestApplicant(t, models.Applicant{})
+	server.Set(applicantCacheKey(first.ID), "x")
+	// Auditing the deletes fails after the rows are already deleted
+	if err := database.DB.Migrator().DropTable(&models.AuditLog{}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := newJSONRequest(t, "DELETE", "/applicants/batch", map[string][]uint{"ids": {first.ID, second.ID}})
+	req.Header.Set(testRoleHeader, "admin")
+	resp, body := doRequest(t, newApplicantTestApp(), req)
+	if resp.StatusCode != 500 {
+		t.Fatalf("status = %d, want 500: %s", resp.StatusCode, body)
+	}
+
+	var count int64
+	database.DB.Model(&models.Applicant{}).Count(&count)
+	if count != 2 {
+		t.Errorf("%d applicants left, want the deletes rolled back", count)
+	}
+	if !server.Exists(applicantCacheKey(first.ID)) || len(*published) != 0 {
+		t.Errorf("cache keys %v and events %v, want no side effects of the failed batch", server.Keys(), eventTypes(*published))
+	}
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"job-tracker/models"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// boardStatuses are the kanban columns, in pipeline order
+var boardStatuses = []string{"pending", "reviewed", "interviewed", "hired", "rejected"}
+
+// boardColumn is one status column of the applicant board
+type boardColumn struct {
+	Data  []models.Applicant `json:"data"`
+	Total int64              `json:"total"`
+	Page  int                `json:"page"`
+	Limit int                `json:"limit"`
+}
+
+// GetApplicantBoard returns applicants grouped into one column per status.
+// Each column is paginated on its own: `limit` sets the column size and
+// `page_<status>` (for example page_pending=2) selects the page of a column.
+func GetApplicantBoard(c *fiber.Ctx) error {
+	limit, err := strconv.Atoi(c.Query("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		return c.Status(400).JSON(fiber.Map{"error": "limit must be between 1 and 100"})
+	}
+
+	filters, err := parseApplicantFilters(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	columns := make(map[string]boardColumn, len(boardStatuses))
+	for _, status := range boardStatuses {
+		page, err := strconv.Atoi(c.Query("page_"+status, "1"))
+		if err != nil || page < 1 {
+			return c.Status(400).JSON(fiber.Map{"error": "page_" + status + " must be a positive integer"})
+		}
+
+		column := boardColumn{Data: []models.Applicant{}, Page: page, Limit: limit}
+		// New session so the count and the page query don't share statement state
+		query := applicantQuery(filters).Where("status = ?", status).Session(&gorm.Session{})
+
+		if err := query.Count(&column.Total).Error; err != nil {
+			log.Printf("Database error counting %s applicants: %v", status, err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicant board"})
+		}
+		if err := query.Scopes(withTags).Order(applicantListOrder).
+			Offset((page - 1) * limit).Limit(limit).
+			Find(&column.Data).Error; err != nil {
+			log.Printf("Database error fetching %s applicants: %v", status, err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch applicant board"})
+		}
+
+		localizeApplicants(column.Data, loc)
+		columns[status] = column
+	}
+
+	return c.JSON(fiber.Map{
+		"statuses": boardStatuses,
+		"columns":  columns,
+	})
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/events"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// bulkCreateResult is the outcome of a bulk create for one item, by its
+// position in the request
+type bulkCreateResult struct {
+	Index  int    `json:"index"`
+	ID     uint   `json:"id,omitempty"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+	Field  string `json:"field,omitempty"`
+}
+
+// applicantError is a rejected bulk item, with the message and field to
+// report it with
+type applicantError struct {
+	Message string
+	Field   string
+}
+
+// joinFieldErrors reports every problem with an item as one error, naming
+// the first field
+func joinFieldErrors(errs []utils.FieldError) *applicantError {
+	messages := make([]string, len(errs))
+	for i, fieldErr := range errs {
+		messages[i] = fieldErr.Message
+	}
+	return &applicantError{Message: strings.Join(messages, "; "), Field: errs[0].Field}
+}
+
+// BulkCreateApplicants creates up to MAX_BATCH_SIZE applicants from a JSON
+// array in the request transaction. Every item is checked the way CreateApplicant
+// checks a single applicant, and duplicate emails within the batch are
+// rejected too. If any item fails, nothing is created and the response is a
+// 422 reporting each item as "valid" or "invalid" with the reason.
+func BulkCreateApplicants(c *fiber.Ctx) error {
+	var items []map[string]interface{}
+	if err := json.Unmarshal(c.Body(), &items); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "body must be a JSON array of applicants"})
+	}
+	if len(items) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "body must contain at least one applicant"})
+	}
+	if len(items) > maxBatchSize {
+		return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("body must contain at most %d applicants", maxBatchSize)})
+	}
+
+	db := middleware.DB(c)
+	applicants := make([]models.Applicant, len(items))
+	results := make([]bulkCreateResult, len(items))
+	emails := make(map[string]int, len(items))
+	failed := false
+	for i, item := range items {
+		results[i] = bulkCreateResult{Index: i, Result: "valid"}
+		if invalid := prepareBulkItem(item, &applicants[i]); invalid != nil {
+			results[i] = bulkCreateResult{Index: i, Result: "invalid", Error: invalid.Message, Field: invalid.Field}
+			failed = true
+			continue
+		}
+
+		if first, seen := emails[applicants[i].CanonicalEmail]; seen {
+			results[i] = bulkCreateResult{Index: i, Result: "invalid", Error: fmt.Sprintf("Email repeats item %d", first), Field: "email"}
+			failed = true
+			continue
+		}
+		emails[applicants[i].CanonicalEmail] = i
+
+		var existing models.Applicant
+		err := emailQuery(db, applicants[i].Email).First(&existing).Error
+		switch {
+		case err == nil:
+			results[i] = bulkCreateResult{Index: i, Result: "invalid", Error: "Email already exists", Field: "email"}
+			failed = true
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			log.Printf("[%s] Database error checking email: %v", middleware.RequestID(c), err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to create applicants"})
+		}
+	}
+	if failed {
+		return c.Status(422).JSON(fiber.Map{"created": 0, "results": results})
+	}
+
+	// All or nothing: any failure below rolls back the whole batch
+	actor := currentUser(c)
+	restored := make([]bool, len(applicants))
+	changes := make([]map[string]models.FieldChange, len(applicants))
+	itemConflict := func(index int, message string) error {
+		for i := range results {
+			results[i].Result = "not_created"
+		}
+		results[index] = bulkCreateResult{Index: index, Result: "invalid", Error: message, Field: "email"}
+		return c.Status(409).JSON(fiber.Map{"created": 0, "results": results})
+	}
+	for i := range applicants {
+		var err error
+		changes[i], restored[i], err = reuseDeletedEmail(db, &applicants[i])
+		if errors.Is(err, errEmailHeldByDeleted) {
+			return itemConflict(i, "Email belongs to a deleted applicant")
+		}
+		if err != nil {
+			log.Printf("[%s] Database error reusing email for bulk item %d: %v", middleware.RequestID(c), i, err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to create applicants"})
+		}
+		if restored[i] {
+			if err := recordAudit(db, applicants[i].ID, "restore", actor, changes[i], "reapplied with the same email"); err != nil {
+				log.Printf("[%s] Database error auditing bulk restore: %v", middleware.RequestID(c), err)
+				return c.Status(500).JSON(fiber.Map{"error": "Failed to create applicants"})
+			}
+			continue
+		}
+
+		if err := db.Create(&applicants[i]).Error; err != nil {
+			// Another request may have taken the email since the check above
+			if database.IsUniqueViolation(err) {
+				return itemConflict(i, "Email already exists")
+			}
+			log.Printf("[%s] Database error bulk creating applicants: %v", middleware.RequestID(c), err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to create applicants"})
+		}
+		if err := recordAudit(db, applicants[i].ID, "create", actor, nil, "bulk import"); err != nil {
+			log.Printf("[%s] Database error auditing bulk create: %v", middleware.RequestID(c), err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to create applicants"})
+		}
+	}
+
+	created := 0
+	for i := range applicants {
+		results[i] = bulkCreateResult{Index: i, ID: applicants[i].ID, Result: "created"}
+		if restored[i] {
+			results[i].Result = "restored"
+		} else {
+			created++
+		}
+	}
+	middleware.AfterCommit(c, func() {
+		invalidateApplicantListCache()
+		for i := range applicants {
+			if restored[i] {
+				invalidateApplicantCache(applicants[i].ID)
+			}
+			publishEvent(events.ApplicantCreated, applicants[i], actor, changes[i])
+		}
+		if created > 0 {
+			rdb.IncrBy(ctx, createdCounterKey, int64(created))
+		}
+	})
+	log.Printf("Bulk created %d applicants, restored %d", created, len(applicants)-created)
+
+	return c.Status(201).JSON(fiber.Map{"created": created, "restored": len(applicants) - created, "results": results})
+}
+
+// prepareBulkItem checks one item against ApplicantCreateSchema, as
+// ValidateBody does for a single create, then decodes and validates it
+func prepareBulkItem(item map[string]interface{}, applicant *models.Applicant) *applicantError {
+	if errs := ApplicantCreateSchema.Validate(item); len(errs) > 0 {
+		// Schema messages leave out the field name
+		for i := range errs {
+			errs[i].Message = errs[i].Field + " " + errs[i].Message
+		}
+		return joinFieldErrors(errs)
+	}
+
+	encoded, err := json.Marshal(item)
+	if err != nil {
+		return &applicantError{Message: "Invalid applicant"}
+	}
+	if err := json.Unmarshal(encoded, applicant); err != nil {
+		return &applicantError{Message: "Invalid applicant"}
+	}
+	if errs := prepareNewApplicant(applicant); len(errs) > 0 {
+		return joinFieldErrors(errs)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"job-tracker/database"
+	"job-tracker/models"
+	"testing"
+)
+
+func TestBulkCreateRollsBackOnFailure(t *testing.T) {
+	_, server, queue := useTestBackends(t)
+	published := useTestEvents(t, queue)
+	// Auditing the first insert fails after the row is written
+	if err := database.DB.Migrator().DropTable(&models.AuditLog{}); err != nil {
+		t.Fatal(err)
+	}
+
+	items := []interface{}{reapply("ada@example.com"), reapply("grace@example.com")}
+	resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "POST", "/applicants/bulk", items))
+	if resp.StatusCode != 500 {
+		t.Fatalf("status = %d, want 500: %s", resp.StatusCode, body)
+	}
+
+	var count int64
+	database.DB.Model(&models.Applicant{}).Count(&count)
+	if count != 0 {
+		t.Errorf("%d applicants saved, want the batch rolled back", count)
+	}
+	if server.Exists(createdCounterKey) || len(*published) != 0 {
+		t.Errorf("created counter %v and events %v, want no side effects of the failed batch", server.Exists(createdCounterKey), eventTypes(*published))
+	}
+}
+
+func TestBulkCreateCommitsAndPublishes(t *testing.T) {
+	_, server, queue := useTestBackends(t)
+	published := useTestEvents(t, queue)
+
+	items := []interface{}{reapply("ada@example.com"), reapply("grace@example.com")}
+	resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "POST", "/applicants/bulk", items))
+	if resp.StatusCode != 201 {
+		t.Fatalf("status = %d, want 201: %s", resp.StatusCode, body)
+	}
+
+	var count int64
+	database.DB.Model(&models.Applicant{}).Count(&count)
+	if count != 2 {
+		t.Errorf("%d applicants saved, want 2", count)
+	}
+	if got, _ := server.Get(createdCounterKey); got != "2" {
+		t.Errorf("created counter = %q, want 2", got)
+	}
+	if len(*published) != 2 {
+		t.Errorf("published %v, want a create event per applicant", eventTypes(*published))
+	}
+}
@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/config"
+	"job-tracker/metrics"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+)
+
+// applicantCachePatterns match every key written by the applicant caches:
+// list pages (applicants_*) and single applicants (applicant_<id>)
+var applicantCachePatterns = []string{"applicants_*", "applicant_*"}
+
+// applicantListCachePattern matches every cached list page, whatever its
+// page, limit, filters or order
+const applicantListCachePattern = "applicants_page_*"
+
+// listCacheTTL is how long a cached applicant list page lives (CACHE_TTL)
+var listCacheTTL = 3 * time.Minute
+
+// applicantCacheTTL is how long a single cached applicant lives, the same as a list page
+var applicantCacheTTL = listCacheTTL
+
+// SetCacheConfig sets how long cached list pages and applicants live and
+// whether the cache is reconciled at startup
+func SetCacheConfig(cfg config.CacheConfig) {
+	listCacheTTL = cfg.TTL
+	applicantCacheTTL = cfg.TTL
+	reconcileOnStart = cfg.ReconcileOnStart
+	cacheWarmPages = cfg.WarmPages
+}
+
+// applicantCacheKey is the key of a single cached applicant
+func applicantCacheKey(id uint) string {
+	return fmt.Sprintf("applicant_%d", id)
+}
+
+// Cache hit/miss counters for the applicant list, since process start
+var (
+	cacheHits   int64
+	cacheMisses int64
+)
+
+// recordCacheLookup counts a list cache hit or miss and updates the hit
+// ratio gauge
+func recordCacheLookup(hit bool) {
+	if hit {
+		atomic.AddInt64(&cacheHits, 1)
+		metrics.CacheLookups.WithLabelValues("hit").Inc()
+	} else {
+		atomic.AddInt64(&cacheMisses, 1)
+		metrics.CacheLookups.WithLabelValues("miss").Inc()
+	}
+	hits, misses := atomic.LoadInt64(&cacheHits), atomic.LoadInt64(&cacheMisses)
+	metrics.CacheHitRatio.Set(float64(hits) / float64(hits+misses))
+}
+
+// scanBatchSize is the COUNT hint of each SCAN and the most keys sent in one DEL
+const scanBatchSize = 100
+
+// scanKeys collects all keys matching any of the patterns. It walks the full SCAN cursor
+// instead of using KEYS so Redis is never blocked on a large keyspace.
+func scanKeys(patterns ...string) ([]string, error) {
+	var keys []string
+	for _, pattern := range patterns {
+		var cursor uint64
+		for {
+			batch, next, err := rdb.Scan(ctx, cursor, pattern, scanBatchSize).Result()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, batch...)
+			cursor = next
+			if cursor == 0 {
+				break
+			}
+		}
+	}
+	return keys, nil
+}
+
+// deleteKeysByPattern removes every key matching any of the patterns and
+// returns how many keys were removed. Keys are collected before any is
+// deleted, since deleting while the SCAN cursor moves can skip keys on some
+// servers; the DELs are sent in batches of the SCAN size.
+func deleteKeysByPattern(patterns ...string) (int64, error) {
+	keys, err := scanKeys(patterns...)
+	if err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for start := 0; start < len(keys); start += scanBatchSize {
+		end := start + scanBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		n, err := rdb.Del(ctx, keys[start:end]...).Result()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
+
+// invalidateApplicantListCache drops every cached list page after applicants
+// change. A Redis failure is logged, not returned: the write has already been
+// saved, and stale pages expire with listCacheTTL.
+func invalidateApplicantListCache() {
+	if _, err := deleteKeysByPattern(applicantListCachePattern); err != nil {
+		log.Printf("Redis error invalidating applicant list cache: %v", err)
+	}
+}
+
+// invalidateApplicantCache drops the cached copies of the given applicants.
+// Like the list invalidation, a Redis failure is only logged.
+func invalidateApplicantCache(ids ...uint) {
+	if len(ids) == 0 {
+		return
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = applicantCacheKey(id)
+	}
+	if err := rdb.Del(ctx, keys...).Err(); err != nil {
+		log.Printf("Redis error invalidating cached applicants %v: %v", ids, err)
+	}
+}
+
+// GetCacheStats reports how many applicant list pages and applicants are cached, an estimate
+// of the memory they use and the list cache hit/miss counters
+func GetCacheStats(c *fiber.Ctx) error {
+	keys, err := scanKeys(applicantCachePatterns...)
+	if err != nil {
+		log.Printf("Redis error scanning cache keys: %v", err)
+		return c.Status(503).JSON(fiber.Map{"error": "Cache unavailable"})
+	}
+
+	// MEMORY USAGE per key, pipelined to keep it to one round-trip
+	var memory int64
+	if len(keys) > 0 {
+		pipe := rdb.Pipeline()
+		usages := make([]*redis.IntCmd, len(keys))
+		for i, key := range keys {
+			usages[i] = pipe.MemoryUsage(ctx, key)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			// Keys can expire between SCAN and MEMORY USAGE; count what we got
+			log.Printf("Redis error reading memory usage: %v", err)
+		}
+		for _, usage := range usages {
+			if bytes, err := usage.Result(); err == nil {
+				memory += bytes
+			}
+		}
+	}
+
+	hits := atomic.LoadInt64(&cacheHits)
+	misses := atomic.LoadInt64(&cacheMisses)
+	hitRatio := 0.0
+	if hits+misses > 0 {
+		hitRatio = float64(hits) / float64(hits+misses)
+	}
+
+	return c.JSON(fiber.Map{
+		"patterns":     applicantCachePatterns,
+		"keys":         len(keys),
+		"memory_bytes": memory,
+		"hits":         hits,
+		"misses":       misses,
+		"hit_ratio":    hitRatio,
+	})
+}
+
+// FlushApplicantCache deletes all cached applicant list pages and applicants,
+// leaving any other Redis data untouched
+func FlushApplicantCache(c *fiber.Ctx) error {
+	deleted, err := deleteKeysByPattern(applicantCachePatterns...)
+	if err != nil {
+		log.Printf("Redis error flushing applicant cache: %v", err)
+		return c.Status(503).JSON(fiber.Map{"error": "Cache unavailable", "deleted": deleted})
+	}
+
+	log.Printf("Flushed %d applicant cache keys", deleted)
+	return c.JSON(fiber.Map{
+		"patterns": applicantCachePatterns,
+		"deleted":  deleted,
+	})
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/middleware"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func seedCacheKeys(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		"applicants_page_1_limit_10_sort_created_at_desc",
+		"applicants_page_2_limit_20_status_pending_sort_created_at_desc",
+		"applicant_1",
+		"applicant_42",
+		createdCounterKey,
+		reviewLockKey(1),
+		"session:abc",
+	} {
+		if err := rdb.Set(ctx, key, "x", 0).Err(); err != nil {
+			t.Fatalf("seed %s: %v", key, err)
+		}
+	}
+}
+
+func TestFlushApplicantCacheDeletesListPagesAndApplicants(t *testing.T) {
+	server := useTestRedis(t)
+	seedCacheKeys(t)
+
+	app := fiber.New()
+	app.Post("/admin/cache/flush", FlushApplicantCache)
+	resp, body := doRequest(t, app, httptest.NewRequest("POST", "/admin/cache/flush", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Deleted int64 `json:"deleted"`
+	}
+	decodeJSON(t, body, &result)
+	if result.Deleted != 4 {
+		t.Errorf("deleted = %d, want 4", result.Deleted)
+	}
+
+	remaining := server.Keys()
+	sort.Strings(remaining)
+	want := []string{reviewLockKey(1), "session:abc", createdCounterKey}
+	sort.Strings(want)
+	if len(remaining) != len(want) {
+		t.Fatalf("remaining keys = %v, want %v", remaining, want)
+	}
+	for i := range want {
+		if remaining[i] != want[i] {
+			t.Fatalf("remaining keys = %v, want %v", remaining, want)
+		}
+	}
+}
+
+func TestFlushApplicantCacheRedisDown(t *testing.T) {
+	server := useTestRedis(t)
+	server.Close()
+
+	app := fiber.New()
+	app.Post("/admin/cache/flush", FlushApplicantCache)
+	resp, body := doRequest(t, app, httptest.NewRequest("POST", "/admin/cache/flush", nil))
+	if resp.StatusCode != 503 {
+		t.Fatalf("status = %d, want 503, body %s", resp.StatusCode, body)
+	}
+}
+
+func TestGetCacheStatsCountsBothNamespaces(t *testing.T) {
+	useTestRedis(t)
+	seedCacheKeys(t)
+
+	app := fiber.New()
+	app.Get("/admin/cache/stats", GetCacheStats)
+	resp, body := doRequest(t, app, httptest.NewRequest("GET", "/admin/cache/stats", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+
+	var stats struct {
+		Keys int `json:"keys"`
+	}
+	decodeJSON(t, body, &stats)
+	if stats.Keys != 4 {
+		t.Errorf("keys = %d, want 4", stats.Keys)
+	}
+}
+
+func TestFlushApplicantCacheWalksEverySCANPage(t *testing.T) {
+	server := useTestRedis(t)
+	// More keys than one SCAN batch, next to keys that only share a prefix
+	for i := 0; i < 250; i++ {
+		server.Set(fmt.Sprintf("applicants_page_%d_limit_10", i), "x")
+	}
+	server.Set("applicants", "x")
+	server.Set("applicant:settings", "x")
+
+	app := fiber.New()
+	app.Post("/admin/cache/flush", FlushApplicantCache)
+	resp, body := doRequest(t, app, httptest.NewRequest("POST", "/admin/cache/flush", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Deleted int64 `json:"deleted"`
+	}
+	decodeJSON(t, body, &result)
+	if result.Deleted != 250 {
+		t.Errorf("deleted = %d, want 250", result.Deleted)
+	}
+	remaining := server.Keys()
+	sort.Strings(remaining)
+	if want := []string{"applicant:settings", "applicants"}; strings.Join(remaining, ",") != strings.Join(want, ",") {
+		t.Errorf("remaining keys = %v, want %v", remaining, want)
+	}
+}
+
+func TestFlushApplicantCacheRequiresAdmin(t *testing.T) {
+	server := useTestRedis(t)
+	seedCacheKeys(t)
+	before := len(server.Keys())
+
+	app := newTestApp()
+	app.Post("/admin/cache/flush", middleware.RequireRole("admin"), FlushApplicantCache)
+	req := httptest.NewRequest("POST", "/admin/cache/flush", nil)
+	resp, body := doRequest(t, app, req)
+	if resp.StatusCode != 403 {
+		t.Fatalf("recruiter status = %d, want 403, body %s", resp.StatusCode, body)
+	}
+	if got := len(server.Keys()); got != before {
+		t.Fatalf("recruiter flush left %d keys, want %d", got, before)
+	}
+
+	req = httptest.NewRequest("POST", "/admin/cache/flush", nil)
+	req.Header.Set(testRoleHeader, "admin")
+	if resp, body := doRequest(t, app, req); resp.StatusCode != 200 {
+		t.Fatalf("admin status = %d, want 200, body %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/events"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// CloneRequest is the body accepted by CloneApplicant
+type CloneRequest struct {
+	Email string `json:"email"`
+}
+
+// errEmailTaken is returned when a new applicant's email is already in use
+var errEmailTaken = errors.New("email already exists")
+
+// CloneApplicant copies an applicant's details into a new applicant with the
+// email given in the body. The copy starts in the default status with a fresh
+// history; audit entries are not copied.
+func CloneApplicant(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var req CloneRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	email := strings.ToLower(utils.SanitizeString(req.Email))
+	if !utils.ValidateEmail(email) {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid email format"})
+	}
+
+	var source models.Applicant
+	if err := database.DB.First(&source, id).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+	}
+
+	clone := models.Applicant{
+		Name:            source.Name,
+		Email:           email,
+		CanonicalEmail:  canonicalEmail(email),
+		Position:        source.Position,
+		Status:          defaultStatus,
+		Phone:           source.Phone,
+		PhoneExtension:  source.PhoneExtension,
+		Resume:          source.Resume,
+		Notes:           source.Notes,
+		LinkedInURL:     source.LinkedInURL,
+		PortfolioURL:    source.PortfolioURL,
+		ExpectedSalary:  source.ExpectedSalary,
+		SalaryCurrency:  source.SalaryCurrency,
+		StatusChangedAt: time.Now().UTC(),
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		// Deleted applicants still hold their email in the unique constraint
+		var taken int64
+		if err := emailQuery(tx.Unscoped().Model(&models.Applicant{}), email).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return errEmailTaken
+		}
+
+		if err := tx.Create(&clone).Error; err != nil {
+			return err
+		}
+		return recordAudit(tx, clone.ID, "create", currentUser(c), nil, fmt.Sprintf("cloned from applicant %d", source.ID))
+	})
+	// A concurrent create can take the email after the count
+	if errors.Is(err, errEmailTaken) || database.IsUniqueViolation(err) {
+		return c.Status(409).JSON(fiber.Map{"error": "Email already exists"})
+	}
+	if err != nil {
+		log.Printf("Database error cloning applicant %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to clone applicant"})
+	}
+
+	invalidateApplicantListCache()
+	rdb.Incr(ctx, createdCounterKey)
+	publishEvent(events.ApplicantCreated, clone, currentUser(c), nil)
+	log.Printf("Cloned applicant %d into new applicant %d", source.ID, clone.ID)
+
+	return c.Status(201).JSON(clone)
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"testing"
+)
+
+func TestCloneApplicant(t *testing.T) {
+	useTestBackends(t)
+	salary := int64(90000)
+	source := createTestApplicant(t, models.Applicant{
+		Name:           "Ada Lovelace",
+		Email:          "ada@example.com",
+		Position:       "Engineer",
+		Status:         utils.StatusInterviewed,
+		Notes:          "Strong referral",
+		LinkedInURL:    "https://www.linkedin.com/in/ada",
+		ExpectedSalary: &salary,
+		SalaryCurrency: "USD",
+		Tags:           models.TagList{{Name: "referral"}},
+	})
+
+	req := newJSONRequest(t, "POST", fmt.Sprintf("/applicants/%d/clone", source.ID), map[string]string{"email": " Ada.Copy@Example.com "})
+	resp, body := doRequest(t, newApplicantTestApp(), req)
+	if resp.StatusCode != 201 {
+		t.Fatalf("status = %d, want 201: %s", resp.StatusCode, body)
+	}
+	var clone models.Applicant
+	decodeJSON(t, body, &clone)
+	if clone.ID == source.ID || clone.Email != "ada.copy@example.com" {
+		t.Errorf("clone %d <%s>, want a new applicant with the normalized email", clone.ID, clone.Email)
+	}
+	if clone.Name != source.Name || clone.Position != source.Position || clone.Notes != source.Notes ||
+		clone.LinkedInURL != source.LinkedInURL || clone.ExpectedSalary == nil || *clone.ExpectedSalary != salary {
+		t.Errorf("clone = %+v, want the source's details", clone)
+	}
+	if clone.Status != utils.StatusPending {
+		t.Errorf("clone status = %q, want the default %q", clone.Status, utils.StatusPending)
+	}
+
+	tagCount := func(id uint) int64 {
+		var n int64
+		database.DB.Table("applicant_tags").Where("applicant_id = ?", id).Count(&n)
+		return n
+	}
+	if source, copied := tagCount(source.ID), tagCount(clone.ID); source != 1 || copied != 0 {
+		t.Errorf("source has %d tags and clone %d, want the tag left on the source only", source, copied)
+	}
+	if actions := auditActions(t, clone.ID); len(actions) != 1 || actions[0] != "create" {
+		t.Errorf("clone audit actions = %v, want [create]", actions)
+	}
+}
+
+func TestCloneApplicantEmailConflicts(t *testing.T) {
+	useTestBackends(t)
+	cfg := defaultApplicantConfig()
+	cfg.EmailNormalizeProviders = []string{"gmail.com"}
+	useApplicantConfig(t, cfg)
+	source := createTestApplicant(t, models.Applicant{Email: "ada@example.com"})
+	createTestApplicant(t, models.Applicant{Email: "grace@example.com"})
+	createTestApplicant(t, models.Applicant{Email: "alan.turing@gmail.com", CanonicalEmail: "alanturing@gmail.com"})
+	deleted := createTestApplicant(t, models.Applicant{Email: "deleted@example.com"})
+	if err := database.DB.Delete(&deleted).Error; err != nil {
+		t.Fatal(err)
+	}
+	app := newApplicantTestApp()
+
+	tests := []struct {
+		name   string
+		email  string
+		status int
+	}{
+		{"source email", "ada@example.com", 409},
+		{"other applicant", "grace@example.com", 409},
+		{"different case", "GRACE@example.com", 409},
+		{"provider variant", "alanturing+jobs@gmail.com", 409},
+		{"deleted applicant", "deleted@example.com", 409},
+		{"invalid", "not-an-email", 400},
+		{"empty", "", 422},
+	}
+	for _, tc := range tests {
+		req := newJSONRequest(t, "POST", fmt.Sprintf("/applicants/%d/clone", source.ID), map[string]string{"email": tc.email})
+		if resp, body := doRequest(t, app, req); resp.StatusCode != tc.status {
+			t.Errorf("%s: status = %d, want %d, body %s", tc.name, resp.StatusCode, tc.status, body)
+		}
+	}
+
+	var count int64
+	database.DB.Unscoped().Model(&models.Applicant{}).Count(&count)
+	if count != 4 {
+		t.Errorf("%d applicants, want the rejected clones to add none", count)
+	}
+}
+
+func TestCloneApplicantNotFound(t *testing.T) {
+	useTestBackends(t)
+	req := newJSONRequest(t, "POST", "/applicants/9999/clone", map[string]string{"email": "ada@example.com"})
+	if resp, _ := doRequest(t, newApplicantTestApp(), req); resp.StatusCode != 404 {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// CommentRequest is the body accepted by CreateComment
+type CommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CreateComment adds a comment to an applicant's thread, authored by the
+// authenticated user
+func CreateComment(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var req CommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	req.Body = utils.SanitizeString(req.Body)
+	if req.Body == "" {
+		return c.Status(422).JSON(fiber.Map{"error": "body must not be empty", "field": "body"})
+	}
+	if !utils.ValidateMaxLength(req.Body, maxCommentLength) {
+		return c.Status(422).JSON(fiber.Map{
+			"error": fmt.Sprintf("body must be at most %d characters", maxCommentLength),
+			"field": "body",
+		})
+	}
+
+	author := currentUser(c)
+	if author == "" {
+		return c.Status(401).JSON(fiber.Map{"error": "Authentication required"})
+	}
+
+	if err := database.DB.Select("id").First(&models.Applicant{}, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+		}
+		log.Printf("[%s] Database error loading applicant %d: %v", middleware.RequestID(c), id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to add comment"})
+	}
+
+	comment := models.Comment{ApplicantID: id, AuthorID: author, Body: req.Body}
+	if err := database.DB.Create(&comment).Error; err != nil {
+		log.Printf("[%s] Database error adding comment to applicant %d: %v", middleware.RequestID(c), id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to add comment"})
+	}
+
+	log.Printf("User %s commented on applicant %d", author, id)
+	return c.Status(201).JSON(comment)
+}
+
+// GetComments returns one page of an applicant's comments, oldest first so
+// the thread reads top to bottom
+func GetComments(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+	page, limit, err := parsePagination(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := database.DB.Select("id").First(&models.Applicant{}, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+		}
+		log.Printf("[%s] Database error loading applicant %d: %v", middleware.RequestID(c), id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch comments"})
+	}
+
+	query := database.DB.Model(&models.Comment{}).Where("applicant_id = ?", id)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		log.Printf("[%s] Database error counting comments of applicant %d: %v", middleware.RequestID(c), id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch comments"})
+	}
+	comments := []models.Comment{}
+	if err := query.
+		Order("created_at ASC, id ASC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&comments).Error; err != nil {
+		log.Printf("[%s] Database error fetching comments of applicant %d: %v", middleware.RequestID(c), id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch comments"})
+	}
+	for i := range comments {
+		comments[i].CreatedAt = comments[i].CreatedAt.In(loc)
+	}
+
+	c.Set(totalCountHeader, strconv.FormatInt(total, 10))
+	return c.JSON(fiber.Map{
+		"data":        comments,
+		"page":        page,
+		"limit":       limit,
+		"total":       total,
+		"total_pages": (total + int64(limit) - 1) / int64(limit),
+	})
+}
+
+// DeleteComment removes a comment from an applicant's thread. Only the
+// comment's author or an admin may delete it.
+func DeleteComment(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+	commentID, err := strconv.ParseUint(c.Params("commentId"), 10, 63)
+	if err != nil || commentID == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid comment id"})
+	}
+
+	var comment models.Comment
+	if err := database.DB.Where("applicant_id = ?", id).First(&comment, commentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "Comment not found"})
+		}
+		log.Printf("[%s] Database error loading comment %d: %v", middleware.RequestID(c), commentID, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete comment"})
+	}
+
+	role, _ := c.Locals("user_role").(string)
+	user := currentUser(c)
+	if role != "admin" && (user == "" || user != comment.AuthorID) {
+		return c.Status(403).JSON(fiber.Map{"error": "Only the author or an admin can delete this comment"})
+	}
+
+	if err := database.DB.Delete(&comment).Error; err != nil {
+		log.Printf("[%s] Database error deleting comment %d: %v", middleware.RequestID(c), commentID, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete comment"})
+	}
+
+	log.Printf("User %s deleted comment %d on applicant %d", user, comment.ID, id)
+	return c.JSON(fiber.Map{"message": "Comment deleted successfully"})
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newCommentTestApp() *fiber.App {
+	app := newTestApp()
+	app.Get("/applicants/:id/comments", GetComments)
+	app.Post("/applicants/:id/comments", middleware.ValidateBody(CommentSchema), CreateComment)
+	app.Delete("/applicants/:id/comments/:commentId", DeleteComment)
+	return app
+}
+
+func TestCreateCommentUsesAuthenticatedAuthor(t *testing.T) {
+	useTestDB(t)
+	applicant := createTestApplicant(t, models.Applicant{})
+	app := newCommentTestApp()
+
+	req := newJSONRequest(t, "POST", fmt.Sprintf("/applicants/%d/comments", applicant.ID), map[string]string{"body": "  Strong portfolio  "})
+	req.Header.Set(testUserHeader, "grace@example.com")
+	resp, body := doRequest(t, app, req)
+	if resp.StatusCode != 201 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+
+	var comment models.Comment
+	decodeJSON(t, body, &comment)
+	if comment.AuthorID != "grace@example.com" || comment.Body != "Strong portfolio" || comment.ApplicantID != applicant.ID {
+		t.Errorf("comment = %+v", comment)
+	}
+}
+
+func TestCreateCommentValidatesBody(t *testing.T) {
+	useTestDB(t)
+	cfg := defaultApplicantConfig()
+	cfg.MaxCommentLength = 10
+	useApplicantConfig(t, cfg)
+	applicant := createTestApplicant(t, models.Applicant{})
+	app := newCommentTestApp()
+	target := fmt.Sprintf("/applicants/%d/comments", applicant.ID)
+
+	tests := []struct {
+		name   string
+		body   interface{}
+		status int
+	}{
+		{"missing body", map[string]string{}, 422},
+		{"blank body", map[string]string{"body": "   "}, 422},
+		{"over MAX_COMMENT_LENGTH", map[string]string{"body": strings.Repeat("a", 11)}, 422},
+		{"at MAX_COMMENT_LENGTH", map[string]string{"body": strings.Repeat("a", 10)}, 201},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := doRequest(t, app, newJSONRequest(t, "POST", target, tt.body))
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d, body %s", resp.StatusCode, tt.status, body)
+			}
+		})
+	}
+}
+
+func TestCreateCommentUnknownApplicant(t *testing.T) {
+	useTestDB(t)
+	app := newCommentTestApp()
+
+	resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants/999/comments", map[string]string{"body": "hi"}))
+	if resp.StatusCode != 404 {
+		t.Errorf("status = %d, want 404, body %s", resp.StatusCode, body)
+	}
+}
+
+func TestGetCommentsPaginatesOldestFirst(t *testing.T) {
+	useTestDB(t)
+	applicant := createTestApplicant(t, models.Applicant{})
+	other := createTestApplicant(t, models.Applicant{})
+	for i := 1; i <= 3; i++ {
+		comment := models.Comment{ApplicantID: applicant.ID, AuthorID: "grace@example.com", Body: fmt.Sprintf("comment %d", i)}
+		if err := database.DB.Create(&comment).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := database.DB.Create(&models.Comment{ApplicantID: other.ID, AuthorID: "x", Body: "elsewhere"}).Error; err != nil {
+		t.Fatal(err)
+	}
+	app := newCommentTestApp()
+
+	resp, body := doRequest(t, app, newJSONRequest(t, "GET", fmt.Sprintf("/applicants/%d/comments?page=2&limit=2", applicant.ID), nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get(totalCountHeader); got != "3" {
+		t.Errorf("%s = %q, want 3", totalCountHeader, got)
+	}
+	var page struct {
+		Data       []models.Comment `json:"data"`
+		Total      int64            `json:"total"`
+		TotalPages int64            `json:"total_pages"`
+	}
+	decodeJSON(t, body, &page)
+	if len(page.Data) != 1 || page.Data[0].Body != "comment 3" {
+		t.Errorf("page 2 = %+v, want only comment 3", page.Data)
+	}
+	if page.Total != 3 || page.TotalPages != 2 {
+		t.Errorf("total, total_pages = %d, %d, want 3, 2", page.Total, page.TotalPages)
+	}
+}
+
+func TestDeleteCommentAuthorization(t *testing.T) {
+	tests := []struct {
+		name       string
+		user, role string
+		status     int
+	}{
+		{"author", "grace@example.com", "recruiter", 200},
+		{"other recruiter", "alan@example.com", "recruiter", 403},
+		{"admin", "admin@example.com", "admin", 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTestDB(t)
+			applicant := createTestApplicant(t, models.Applicant{})
+			comment := models.Comment{ApplicantID: applicant.ID, AuthorID: "grace@example.com", Body: "hello"}
+			if err := database.DB.Create(&comment).Error; err != nil {
+				t.Fatal(err)
+			}
+			app := newCommentTestApp()
+
+			req := newJSONRequest(t, "DELETE", fmt.Sprintf("/applicants/%d/comments/%d", applicant.ID, comment.ID), nil)
+			req.Header.Set(testUserHeader, tt.user)
+			req.Header.Set(testRoleHeader, tt.role)
+			resp, body := doRequest(t, app, req)
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d, body %s", resp.StatusCode, tt.status, body)
+			}
+
+			var count int64
+			database.DB.Model(&models.Comment{}).Where("id = ?", comment.ID).Count(&count)
+			if deleted := count == 0; deleted != (tt.status == 200) {
+				t.Errorf("comment deleted = %v after status %d", deleted, tt.status)
+			}
+		})
+	}
+}
+
+func TestDeleteCommentOfAnotherApplicant(t *testing.T) {
+	useTestDB(t)
+	applicant := createTestApplicant(t, models.Applicant{})
+	other := createTestApplicant(t, models.Applicant{})
+	comment := models.Comment{ApplicantID: other.ID, AuthorID: "recruiter@example.com", Body: "hello"}
+	if err := database.DB.Create(&comment).Error; err != nil {
+		t.Fatal(err)
+	}
+	app := newCommentTestApp()
+
+	resp, body := doRequest(t, app, newJSONRequest(t, "DELETE", fmt.Sprintf("/applicants/%d/comments/%d", applicant.ID, comment.ID), nil))
+	if resp.StatusCode != 404 {
+		t.Errorf("status = %d, want 404, body %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"job-tracker/database"
+	"job-tracker/models"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// createdCounterKey holds the number of applicants ever created. It lives
+// outside the applicants_* and applicant_* namespaces so cache flushes leave
+// it alone.
+const createdCounterKey = "stats:applicants_created_total"
+
+// countCreatedApplicants counts every applicant row, including soft-deleted ones
+func countCreatedApplicants() (int64, error) {
+	var total int64
+	err := database.DB.Unscoped().Model(&models.Applicant{}).Count(&total).Error
+	return total, err
+}
+
+// reconcileCreatedCounter resets the Redis counter from the database and
+// returns the new total
+func reconcileCreatedCounter() (int64, error) {
+	total, err := countCreatedApplicants()
+	if err != nil {
+		return 0, err
+	}
+	return total, rdb.Set(ctx, createdCounterKey, total, 0).Err()
+}
+
+// ReconcileCreatedCounter resets the Redis counter from the database so drift
+// from missed increments doesn't survive a restart
+func ReconcileCreatedCounter() {
+	total, err := reconcileCreatedCounter()
+	if err != nil {
+		log.Printf("Warning: failed to reconcile created applicants counter: %v", err)
+		return
+	}
+	log.Printf("Created applicants counter reconciled to %d", total)
+}
+
+// GetApplicantMetrics returns the total number of applicants ever created from
+// the Redis counter, falling back to a database count when Redis is down
+func GetApplicantMetrics(c *fiber.Ctx) error {
+	total, err := rdb.Get(ctx, createdCounterKey).Int64()
+	source := "cache"
+	if err != nil {
+		log.Printf("Redis error reading created applicants counter: %v", err)
+		if total, err = countCreatedApplicants(); err != nil {
+			log.Printf("Database error counting applicants: %v", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to count applicants"})
+		}
+		source = "database"
+	}
+
+	return c.JSON(fiber.Map{
+		"total_created": total,
+		"source":        source,
+	})
+}
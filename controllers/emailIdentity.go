@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// canonicalEmail normalizes an email for duplicate detection using the
+// configured EMAIL_NORMALIZE_PROVIDERS
+func canonicalEmail(email string) string {
+	return utils.CanonicalEmail(email, emailNormalizeProviders)
+}
+
+// emailQuery matches applicants holding an email, either exactly or through
+// its canonical form, so gmail variants of the same mailbox collide
+func emailQuery(db *gorm.DB, email string) *gorm.DB {
+	return db.Where("(email = ? OR canonical_email = ?)", email, canonicalEmail(email))
+}
+
+// BackfillCanonicalEmails fills in the canonical email of applicants stored
+// before it was tracked, in batches so large tables aren't locked at once
+func BackfillCanonicalEmails() {
+	total := 0
+	for {
+		var applicants []models.Applicant
+		if err := database.DB.Unscoped().Select("id", "email").
+			Where("canonical_email IS NULL OR canonical_email = ''").
+			Limit(500).Find(&applicants).Error; err != nil {
+			log.Printf("Warning: canonical email backfill stopped: %v", err)
+			return
+		}
+		if len(applicants) == 0 {
+			break
+		}
+
+		for _, applicant := range applicants {
+			if err := database.DB.Unscoped().Model(&applicant).
+				UpdateColumn("canonical_email", canonicalEmail(applicant.Email)).Error; err != nil {
+				log.Printf("Warning: canonical email backfill stopped: %v", err)
+				return
+			}
+		}
+		total += len(applicants)
+	}
+	if total > 0 {
+		log.Printf("Backfilled canonical email for %d applicants", total)
+	}
+}
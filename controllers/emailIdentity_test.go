@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"job-tracker/database"
+	"job-tracker/models"
+	"testing"
+)
+
+// useEmailNormalizeProviders sets EMAIL_NORMALIZE_PROVIDERS for the test
+func useEmailNormalizeProviders(t *testing.T, providers ...string) {
+	t.Helper()
+	cfg := defaultApplicantConfig()
+	cfg.EmailNormalizeProviders = providers
+	useApplicantConfig(t, cfg)
+}
+
+func TestCreateRejectsGmailVariants(t *testing.T) {
+	useTestBackends(t)
+	useEmailNormalizeProviders(t, "gmail.com")
+	app := newApplicantTestApp()
+
+	first := map[string]interface{}{"name": "Ada", "email": "ada.lovelace@gmail.com", "position": "Engineer"}
+	resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants", first))
+	if resp.StatusCode != 201 {
+		t.Fatalf("first create: status = %d, want 201: %s", resp.StatusCode, body)
+	}
+	var created models.Applicant
+	decodeJSON(t, body, &created)
+	if created.Email != "ada.lovelace@gmail.com" {
+		t.Errorf("email = %q, want the address as submitted", created.Email)
+	}
+
+	for _, email := range []string{"adalovelace@gmail.com", "a.d.a.lovelace@gmail.com", "ada.lovelace+jobs@gmail.com", "AdaLovelace+x@Gmail.com"} {
+		item := map[string]interface{}{"name": "Ada", "email": email, "position": "Engineer"}
+		if resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants", item)); resp.StatusCode != 409 {
+			t.Errorf("%s: status = %d, want 409, body %s", email, resp.StatusCode, body)
+		}
+		bulk := []interface{}{item}
+		if resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants/bulk", bulk)); resp.StatusCode != 422 {
+			t.Errorf("bulk %s: status = %d, want 422, body %s", email, resp.StatusCode, body)
+		}
+	}
+
+	// Other domains keep dots and tags significant
+	other := map[string]interface{}{"name": "Ada", "email": "ada.lovelace+jobs@example.com", "position": "Engineer"}
+	doRequest(t, app, newJSONRequest(t, "POST", "/applicants", other))
+	other["email"] = "adalovelace@example.com"
+	if resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants", other)); resp.StatusCode != 201 {
+		t.Errorf("other domain: status = %d, want 201, body %s", resp.StatusCode, body)
+	}
+}
+
+func TestGmailVariantsAllowedByDefault(t *testing.T) {
+	useTestBackends(t)
+	app := newApplicantTestApp()
+
+	for _, email := range []string{"ada.lovelace@gmail.com", "adalovelace@gmail.com", "ada.lovelace+jobs@gmail.com"} {
+		item := map[string]interface{}{"name": "Ada", "email": email, "position": "Engineer"}
+		if resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants", item)); resp.StatusCode != 201 {
+			t.Errorf("%s: status = %d, want 201, body %s", email, resp.StatusCode, body)
+		}
+	}
+}
+
+func TestBackfillCanonicalEmails(t *testing.T) {
+	useTestDB(t)
+	useEmailNormalizeProviders(t, "gmail.com")
+	live := createTestApplicant(t, models.Applicant{Email: "ada.lovelace+jobs@gmail.com"})
+	deleted := createTestApplicant(t, models.Applicant{Email: "Grace.Hopper@Example.com"})
+	if err := database.DB.Delete(&deleted).Error; err != nil {
+		t.Fatal(err)
+	}
+	done := createTestApplicant(t, models.Applicant{Email: "alan@example.com", CanonicalEmail: "kept@example.com"})
+
+	BackfillCanonicalEmails()
+
+	want := map[uint]string{
+		live.ID:    "adalovelace@gmail.com",
+		deleted.ID: "grace.hopper@example.com",
+		done.ID:    "kept@example.com",
+	}
+	for id, canonical := range want {
+		var stored models.Applicant
+		if err := database.DB.Unscoped().First(&stored, id).Error; err != nil {
+			t.Fatal(err)
+		}
+		if stored.CanonicalEmail != canonical {
+			t.Errorf("applicant %d canonical_email = %q, want %q", id, stored.CanonicalEmail, canonical)
+		}
+	}
+}
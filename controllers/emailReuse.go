@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"job-tracker/config"
+	"job-tracker/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errEmailHeldByDeleted is returned when the reject policy finds the email on a deleted applicant
+var errEmailHeldByDeleted = errors.New("email belongs to a deleted applicant")
+
+// reuseDeletedEmail applies the email reuse policy inside the create
+// transaction. When the email belongs to a soft-deleted applicant and the policy
+// is restore, that applicant is restored with the details in applicant, which
+// is then reloaded, and the field changes are returned with restored set.
+func reuseDeletedEmail(tx *gorm.DB, applicant *models.Applicant) (changes map[string]models.FieldChange, restored bool, err error) {
+	var deleted models.Applicant
+	err = tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("email = ? AND deleted_at IS NOT NULL", applicant.Email).
+		First(&deleted).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch emailReusePolicy {
+	case config.EmailReuseRestore:
+		// Overwrite every field, including ones the new request leaves empty,
+		// and clear deleted_at
+		if err := tx.Unscoped().Model(&deleted).Select("*").Omit("id", "created_at").Updates(applicant).Error; err != nil {
+			return nil, false, err
+		}
+		before := deleted
+		if err := tx.Scopes(withTags).First(applicant, deleted.ID).Error; err != nil {
+			return nil, false, err
+		}
+		return models.DiffApplicants(before, *applicant), true, nil
+	case config.EmailReuseRelease:
+		// Suffix the deleted applicant's email, trimming it to fit the column
+		suffix := fmt.Sprintf("#deleted-%d", deleted.ID)
+		email := deleted.Email
+		if len(email)+len(suffix) > 150 {
+			email = email[:150-len(suffix)]
+		}
+		return nil, false, tx.Unscoped().Model(&deleted).Updates(map[string]interface{}{
+			"email":           email + suffix,
+			"canonical_email": email + suffix,
+		}).Error
+	default:
+		return nil, false, errEmailHeldByDeleted
+	}
+}
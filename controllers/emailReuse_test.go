@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/config"
+	"job-tracker/database"
+	"job-tracker/models"
+	"testing"
+)
+
+// createDeletedApplicant inserts an applicant with email and soft-deletes it
+func createDeletedApplicant(t *testing.T, email string) models.Applicant {
+	t.Helper()
+	applicant := createTestApplicant(t, models.Applicant{Name: "Old Name", Email: email, Position: "Designer"})
+	if err := database.DB.Delete(&applicant).Error; err != nil {
+		t.Fatal(err)
+	}
+	return applicant
+}
+
+func useEmailReusePolicy(t *testing.T, policy string) {
+	t.Helper()
+	cfg := defaultApplicantConfig()
+	cfg.EmailReusePolicy = policy
+	useApplicantConfig(t, cfg)
+}
+
+func reapply(email string) map[string]interface{} {
+	return map[string]interface{}{"name": "New Name", "email": email, "position": "Engineer"}
+}
+
+func TestReapplyRejected(t *testing.T) {
+	useTestBackends(t)
+	useEmailReusePolicy(t, config.EmailReuseReject)
+	deleted := createDeletedApplicant(t, "ada@example.com")
+
+	resp, _ := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "POST", "/applicants", reapply("ada@example.com")))
+	if resp.StatusCode != 409 {
+		t.Fatalf("status = %d, want 409", resp.StatusCode)
+	}
+
+	var stored models.Applicant
+	if err := database.DB.Unscoped().First(&stored, deleted.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !stored.DeletedAt.Valid || stored.Name != "Old Name" {
+		t.Errorf("deleted applicant changed: deleted = %v, name = %q", stored.DeletedAt.Valid, stored.Name)
+	}
+}
+
+func TestReapplyRestores(t *testing.T) {
+	useTestBackends(t)
+	useEmailReusePolicy(t, config.EmailReuseRestore)
+	deleted := createDeletedApplicant(t, "ada@example.com")
+
+	resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "POST", "/applicants", reapply("ada@example.com")))
+	if resp.StatusCode != 201 {
+		t.Fatalf("status = %d, want 201: %s", resp.StatusCode, body)
+	}
+	var got models.Applicant
+	decodeJSON(t, body, &got)
+	if got.ID != deleted.ID {
+		t.Errorf("id = %d, want the deleted applicant's %d", got.ID, deleted.ID)
+	}
+	if got.Name != "New Name" || got.Position != "Engineer" {
+		t.Errorf("restored applicant = %q/%q, want the reapplied details", got.Name, got.Position)
+	}
+
+	var stored models.Applicant
+	if err := database.DB.First(&stored, deleted.ID).Error; err != nil {
+		t.Fatalf("restored applicant not visible: %v", err)
+	}
+	var count int64
+	database.DB.Unscoped().Model(&models.Applicant{}).Count(&count)
+	if count != 1 {
+		t.Errorf("applicant rows = %d, want 1", count)
+	}
+	if actions := auditActions(t, deleted.ID); len(actions) != 1 || actions[0] != "restore" {
+		t.Errorf("audit actions = %v, want [restore]", actions)
+	}
+}
+
+func TestReapplyReleasesEmail(t *testing.T) {
+	useTestBackends(t)
+	useEmailReusePolicy(t, config.EmailReuseRelease)
+	deleted := createDeletedApplicant(t, "ada@example.com")
+
+	resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "POST", "/applicants", reapply("ada@example.com")))
+	if resp.StatusCode != 201 {
+		t.Fatalf("status = %d, want 201: %s", resp.StatusCode, body)
+	}
+	var got models.Applicant
+	decodeJSON(t, body, &got)
+	if got.ID == deleted.ID || got.Email != "ada@example.com" {
+		t.Errorf("created applicant %d <%s>, want a new applicant with the email", got.ID, got.Email)
+	}
+
+	var stored models.Applicant
+	if err := database.DB.Unscoped().First(&stored, deleted.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("ada@example.com#deleted-%d", deleted.ID)
+	if stored.Email != want || !stored.DeletedAt.Valid {
+		t.Errorf("deleted applicant email = %q (deleted %v), want %q still deleted", stored.Email, stored.DeletedAt.Valid, want)
+	}
+}
+
+func TestReapplyLiveEmailStillConflicts(t *testing.T) {
+	for _, policy := range []string{config.EmailReuseReject, config.EmailReuseRestore, config.EmailReuseRelease} {
+		t.Run(policy, func(t *testing.T) {
+			useTestBackends(t)
+			useEmailReusePolicy(t, policy)
+			createTestApplicant(t, models.Applicant{Email: "ada@example.com"})
+
+			resp, _ := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "POST", "/applicants", reapply("ada@example.com")))
+			if resp.StatusCode != 409 {
+				t.Errorf("status = %d, want 409", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestBulkReapply(t *testing.T) {
+	tests := []struct {
+		policy       string
+		wantStatus   int
+		wantResult   string
+		wantCreated  int
+		wantRestored int
+	}{
+		{config.EmailReuseReject, 409, "invalid", 0, 0},
+		{config.EmailReuseRestore, 201, "restored", 1, 1},
+		{config.EmailReuseRelease, 201, "created", 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			useTestBackends(t)
+			useEmailReusePolicy(t, tt.policy)
+			createDeletedApplicant(t, "ada@example.com")
+
+			items := []interface{}{reapply("ada@example.com"), reapply("grace@example.com")}
+			resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "POST", "/applicants/bulk", items))
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", resp.StatusCode, tt.wantStatus, body)
+			}
+			var got struct {
+				Created  int                `json:"created"`
+				Restored int                `json:"restored"`
+				Results  []bulkCreateResult `json:"results"`
+			}
+			decodeJSON(t, body, &got)
+			if len(got.Results) != 2 || got.Results[0].Result != tt.wantResult {
+				t.Errorf("results = %+v, want the reapplication %q", got.Results, tt.wantResult)
+			}
+			if got.Created != tt.wantCreated || got.Restored != tt.wantRestored {
+				t.Errorf("created = %d, restored = %d, want %d and %d", got.Created, got.Restored, tt.wantCreated, tt.wantRestored)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"job-tracker/models"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// exportField is a column that can be included in an export
+type exportField struct {
+	Column string
+	Value  func(a *models.Applicant) string
+}
+
+// exportFields is the whitelist of exportable fields, keyed by their JSON name
+var exportFields = map[string]exportField{
+	"id":                {"id", func(a *models.Applicant) string { return strconv.FormatUint(uint64(a.ID), 10) }},
+	"name":              {"name", func(a *models.Applicant) string { return a.Name }},
+	"email":             {"email", func(a *models.Applicant) string { return a.Email }},
+	"position":          {"position", func(a *models.Applicant) string { return a.Position }},
+	"status":            {"status", func(a *models.Applicant) string { return string(a.Status) }},
+	"phone":             {"phone", func(a *models.Applicant) string { return string(a.Phone) }},
+	"phone_extension":   {"phone_extension", func(a *models.Applicant) string { return a.PhoneExtension }},
+	"notes":             {"notes", func(a *models.Applicant) string { return a.Notes }},
+	"linkedin_url":      {"linked_in_url", func(a *models.Applicant) string { return a.LinkedInURL }},
+	"portfolio_url":     {"portfolio_url", func(a *models.Applicant) string { return a.PortfolioURL }},
+	"expected_salary":   {"expected_salary", func(a *models.Applicant) string { return formatSalary(a.ExpectedSalary) }},
+	"salary_currency":   {"salary_currency", func(a *models.Applicant) string { return a.SalaryCurrency }},
+	"created_at":        {"created_at", func(a *models.Applicant) string { return a.CreatedAt.Format(time.RFC3339) }},
+	"updated_at":        {"updated_at", func(a *models.Applicant) string { return a.UpdatedAt.Format(time.RFC3339) }},
+	"status_changed_at": {"status_changed_at", func(a *models.Applicant) string { return a.StatusChangedAt.Format(time.RFC3339) }},
+}
+
+// formatSalary renders an optional salary, leaving the cell empty when unset
+func formatSalary(salary *int64) string {
+	if salary == nil {
+		return ""
+	}
+	return strconv.FormatInt(*salary, 10)
+}
+
+// defaultExportFields are exported when no field selection is given
+var defaultExportFields = []string{"id", "name", "email", "position", "status", "phone", "created_at"}
+
+// parseExportFields validates the comma-separated `fields` parameter against
+// the whitelist, keeping the requested order and dropping duplicates
+func parseExportFields(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return defaultExportFields, nil
+	}
+
+	var fields []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if _, ok := exportFields[name]; !ok {
+			return nil, fmt.Errorf("unknown export field %q", name)
+		}
+		seen[name] = true
+		fields = append(fields, name)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("fields must name at least one field")
+	}
+	return fields, nil
+}
+
+// exportJSONValue is a field's value in a JSON export. Numbers stay numbers
+// and an unset salary is null; everything else is the CSV text.
+func exportJSONValue(name string, a *models.Applicant) interface{} {
+	switch name {
+	case "id":
+		return a.ID
+	case "expected_salary":
+		return a.ExpectedSalary
+	}
+	return exportFields[name].Value(a)
+}
+
+// writeExportObject writes one applicant as a JSON object with the fields in
+// the requested order
+func writeExportObject(buf *bytes.Buffer, fields []string, a *models.Applicant) error {
+	buf.WriteByte('{')
+	for i, name := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		value, err := json.Marshal(exportJSONValue(name, a))
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// ExportApplicants streams the applicants matching the list filters as a CSV
+// file or, with format=json, a JSON array of objects. The `fields` parameter
+// selects which columns are exported; both the SELECT and the CSV header or
+// object keys are built from it. Rows are read in keyset chunks of
+// exportChunkSize ordered by id, so memory stays flat and no single query or
+// cursor stays open for the whole export.
+func ExportApplicants(c *fiber.Ctx) error {
+	format := c.Query("format", "csv")
+	if format != "csv" && format != "json" {
+		return c.Status(400).JSON(fiber.Map{"error": "format must be csv or json"})
+	}
+
+	filters, err := parseApplicantFilters(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	fields, err := parseExportFields(c.Query("fields"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// id is always read because it drives the keyset, even if not exported
+	columns := []string{"id"}
+	for _, name := range fields {
+		if name != "id" {
+			columns = append(columns, exportFields[name].Column)
+		}
+	}
+
+	if format == "json" {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	} else {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	}
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="applicants.%s"`, format))
+
+	// Rows are written as they are read so the export never sits in memory
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		var writeApplicant func(a *models.Applicant) error
+		var csvWriter *csv.Writer
+		if format == "json" {
+			w.WriteString("[")
+			var buf bytes.Buffer
+			first := true
+			writeApplicant = func(a *models.Applicant) error {
+				buf.Reset()
+				if !first {
+					buf.WriteByte(',')
+				}
+				first = false
+				if err := writeExportObject(&buf, fields, a); err != nil {
+					return err
+				}
+				_, err := w.Write(buf.Bytes())
+				return err
+			}
+		} else {
+			csvWriter = csv.NewWriter(w)
+			csvWriter.Write(fields)
+			record := make([]string, len(fields))
+			writeApplicant = func(a *models.Applicant) error {
+				for j, name := range fields {
+					record[j] = exportFields[name].Value(a)
+				}
+				return csvWriter.Write(record)
+			}
+		}
+
+		count := 0
+		var lastID uint
+		for {
+			var chunk []models.Applicant
+			if err := applicantQuery(filters).Select(columns).
+				Where("id > ?", lastID).
+				Order("id ASC").
+				Limit(exportChunkSize).
+				Find(&chunk).Error; err != nil {
+				log.Printf("Database error during export after id %d: %v", lastID, err)
+				break
+			}
+
+			for i := range chunk {
+				if err := writeApplicant(&chunk[i]); err != nil {
+					log.Printf("Export aborted after %d applicants: %v", count, err)
+					return
+				}
+			}
+			count += len(chunk)
+
+			// Hand each chunk to the client before reading the next one
+			if csvWriter != nil {
+				csvWriter.Flush()
+			}
+			if err := w.Flush(); err != nil {
+				log.Printf("Export aborted after %d applicants: %v", count, err)
+				return
+			}
+
+			if len(chunk) < exportChunkSize {
+				break
+			}
+			lastID = chunk[len(chunk)-1].ID
+		}
+
+		if format == "json" {
+			w.WriteString("]")
+		}
+		log.Printf("Exported %d applicants", count)
+	})
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"strings"
+	"testing"
+)
+
+func TestParseExportFields(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+		err  string
+	}{
+		{"", defaultExportFields, ""},
+		{"name,email", []string{"name", "email"}, ""},
+		{" Email , name,,email ", []string{"email", "name"}, ""},
+		{"linkedin_url,expected_salary", []string{"linkedin_url", "expected_salary"}, ""},
+		{"name,password", nil, `unknown export field "password"`},
+		{"resume", nil, `unknown export field "resume"`},
+		{",,", nil, "fields must name at least one field"},
+	}
+	for _, tc := range tests {
+		got, err := parseExportFields(tc.raw)
+		if tc.err != "" {
+			if err == nil || err.Error() != tc.err {
+				t.Errorf("parseExportFields(%q) error = %v, want %q", tc.raw, err, tc.err)
+			}
+			continue
+		}
+		if err != nil || strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("parseExportFields(%q) = %v, %v, want %v", tc.raw, got, err, tc.want)
+		}
+	}
+}
+
+func TestExportSelectedFields(t *testing.T) {
+	useTestBackends(t)
+	cfg := defaultApplicantConfig()
+	cfg.ExportChunkSize = 2
+	useApplicantConfig(t, cfg)
+	salary := int64(85000)
+	var created []models.Applicant
+	for i := 0; i < 5; i++ {
+		created = append(created, createTestApplicant(t, models.Applicant{
+			Name: fmt.Sprintf("Applicant %d", i), Status: utils.StatusReviewed,
+			LinkedInURL: fmt.Sprintf("https://www.linkedin.com/in/a%d", i), ExpectedSalary: &salary,
+		}))
+	}
+	app := newApplicantTestApp()
+
+	resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/applicants/export?fields=name,linkedin_url,status", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("csv: status = %d, body %s", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, "text/csv") {
+		t.Errorf("content type = %q, want text/csv", got)
+	}
+	rows, err := csv.NewReader(strings.NewReader(string(body))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"name", "linkedin_url", "status"}}
+	for _, applicant := range created {
+		want = append(want, []string{applicant.Name, applicant.LinkedInURL, "reviewed"})
+	}
+	if fmt.Sprint(rows) != fmt.Sprint(want) {
+		t.Errorf("csv rows = %v, want %v", rows, want)
+	}
+
+	resp, body = doRequest(t, app, newJSONRequest(t, "GET", "/applicants/export?format=json&fields=expected_salary,id", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("json: status = %d, body %s", resp.StatusCode, body)
+	}
+	var objects []map[string]interface{}
+	decodeJSON(t, body, &objects)
+	if len(objects) != len(created) {
+		t.Fatalf("json export has %d objects, want %d", len(objects), len(created))
+	}
+	for i, object := range objects {
+		if len(object) != 2 || object["id"] != float64(created[i].ID) || object["expected_salary"] != float64(salary) {
+			t.Errorf("object %d = %v, want only id and expected_salary", i, object)
+		}
+	}
+	if !strings.HasPrefix(string(body), `[{"expected_salary":`) {
+		t.Errorf("json keys are not in the requested order: %.40s", body)
+	}
+
+	resp, body = doRequest(t, app, newJSONRequest(t, "GET", "/applicants/export?fields=name,password", nil))
+	if resp.StatusCode != 400 {
+		t.Errorf("unknown field: status = %d, want 400, body %s", resp.StatusCode, body)
+	}
+}
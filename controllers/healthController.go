@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"job-tracker/database"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// healthCheckTimeout bounds each dependency ping so a hung dependency fails
+// the readiness check instead of hanging the probe
+const healthCheckTimeout = 2 * time.Second
+
+// dependencyStatus is the outcome of pinging one dependency
+type dependencyStatus struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// checkDependency runs ping with a timeout and reports whether it succeeded
+// and how long it took
+func checkDependency(ping func(ctx context.Context) error) dependencyStatus {
+	pingCtx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := ping(pingCtx)
+	status := dependencyStatus{
+		Status:    "up",
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		status.Status = "down"
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// pingDatabase checks that Postgres answers on a pooled connection
+func pingDatabase(ctx context.Context) error {
+	if database.DB == nil {
+		return errors.New("not connected")
+	}
+	sqlDB, err := database.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// pingRedis checks that Redis answers
+func pingRedis(ctx context.Context) error {
+	if rdb == nil {
+		return errors.New("not connected")
+	}
+	return rdb.Ping(ctx).Err()
+}
+
+// HealthLive reports that the process is up and serving requests. It checks
+// no dependencies, so a liveness probe never restarts the app over an outage
+// elsewhere.
+func HealthLive(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": "alive"})
+}
+
+// HealthReady pings Postgres and Redis and reports each with its latency. It
+// returns 503 when either is unreachable, so a readiness probe takes the
+// instance out of rotation until both are back.
+func HealthReady(c *fiber.Ctx) error {
+	checks := fiber.Map{
+		"database": checkDependency(pingDatabase),
+		"redis":    checkDependency(pingRedis),
+	}
+
+	code, status := 200, "ready"
+	for _, check := range checks {
+		if check.(dependencyStatus).Status != "up" {
+			code, status = 503, "unavailable"
+		}
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(code).JSON(fiber.Map{
+		"status": status,
+		"checks": checks,
+	})
+}
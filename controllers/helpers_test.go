@@ -0,0 +1,310 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"job-tracker/config"
+	"job-tracker/database"
+	"job-tracker/jobs"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// useTestDB points database.DB at an empty in-memory SQLite database with the
+// application tables for the duration of the test. Handlers that rely on
+// PostgreSQL-only SQL, such as trigram search, can't be tested this way.
+func useTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared&_pragma=foreign_keys(1)",
+		strings.NewReplacer("/", "_", " ", "_").Replace(t.Name()))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:  logger.Default.LogMode(logger.Silent),
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("test database handle: %v", err)
+	}
+	// One connection keeps the in-memory database alive and serializes writes
+	sqlDB.SetMaxOpenConns(1)
+
+	err = db.AutoMigrate(&models.Applicant{}, &models.AuditLog{}, &models.User{}, &models.StatusHistory{},
+		&models.Comment{}, &models.Tag{}, &models.Webhook{})
+	if err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() {
+		database.DB = previous
+		sqlDB.Close()
+	})
+	return db
+}
+
+// testQueue runs each job as soon as it is queued and keeps its name
+type testQueue struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (q *testQueue) Enqueue(job jobs.Job) error {
+	q.mu.Lock()
+	q.names = append(q.names, job.Name)
+	q.mu.Unlock()
+	_ = job.Run(context.Background())
+	return nil
+}
+
+// useTestQueue sets the background job queue to a testQueue for the duration of the test
+func useTestQueue(t *testing.T) *testQueue {
+	t.Helper()
+	queue := &testQueue{}
+	previous := jobQueue
+	jobQueue = queue
+	t.Cleanup(func() { jobQueue = previous })
+	return queue
+}
+
+// Test request identity, read by testAuth in place of a JWT
+const (
+	testUserHeader = "X-Test-User"
+	testRoleHeader = "X-Test-Role"
+)
+
+// newTestApp creates an app whose requests are authenticated as the user and
+// role in the test headers, defaulting to recruiter@example.com as a recruiter
+func newTestApp() *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		user, role := c.Get(testUserHeader), c.Get(testRoleHeader)
+		if user == "" {
+			user = "recruiter@example.com"
+		}
+		if role == "" {
+			role = "recruiter"
+		}
+		c.Locals("user_id", user)
+		c.Locals("user_role", role)
+		return c.Next()
+	})
+	return app
+}
+
+// useTestBackends sets up the test database, Redis and job queue most
+// handlers need
+func useTestBackends(t *testing.T) (*gorm.DB, *miniredis.Miniredis, *testQueue) {
+	t.Helper()
+	return useTestDB(t), useTestRedis(t), useTestQueue(t)
+}
+
+// newApplicantTestApp registers the /applicants routes as routes.Setup does,
+// behind the test authentication instead of JWTs, quotas, rate limits and the
+// upload limiter
+func newApplicantTestApp() *fiber.App {
+	app := newTestApp()
+	api := app.Group("/applicants")
+	api.Post("/", middleware.ValidateBody(ApplicantCreateSchema), CreateApplicant)
+	api.Post("/bulk", middleware.Transaction(), BulkCreateApplicants)
+	api.Head("/", HeadApplicants)
+	api.Get("/", GetApplicants)
+	api.Get("/overdue", GetOverdueApplicants)
+	api.Get("/board", GetApplicantBoard)
+	api.Get("/export", ExportApplicants)
+	api.Get("/schema", GetApplicantSchema)
+	api.Get("/:id", GetApplicant)
+	api.Get("/:id/history", GetApplicantStatusHistory)
+	api.Post("/mark-reviewed", middleware.ValidateBody(BatchIDsSchema), MarkApplicantsReviewed)
+	api.Post("/batch", middleware.ValidateBody(BatchIDsSchema), FetchApplicantsBatch)
+	api.Post("/resumes/zip", middleware.ValidateBody(BatchIDsSchema), ZipResumes)
+	api.Delete("/batch", middleware.RequireRole("admin"), middleware.ValidateBody(BatchIDsSchema), middleware.Transaction(), DeleteApplicantsBatch)
+	api.Put("/:id", middleware.ValidateBody(ApplicantUpdateSchema), UpdateApplicant)
+	api.Patch("/:id", middleware.ValidateBody(ApplicantUpdateSchema), PatchApplicant)
+	api.Delete("/:id", middleware.RequireRole("admin"), middleware.Transaction(), DeleteApplicant)
+	api.Post("/:id/transition", middleware.ValidateBody(TransitionSchema), TransitionApplicant)
+	api.Post("/:id/clone", middleware.ValidateBody(CloneSchema), CloneApplicant)
+	api.Post("/:id/tags", middleware.ValidateBody(TagsSchema), AddApplicantTags)
+	api.Delete("/:id/tags/:tag", RemoveApplicantTag)
+	api.Post("/:id/resume", UploadResume)
+	api.Get("/:id/resume", DownloadResume)
+	api.Post("/:id/reopen", middleware.RequireRole(ReopenRoles()...), middleware.ValidateBody(ReopenSchema), ReopenApplicant)
+	return app
+}
+
+// newJSONRequest builds a request with body encoded as JSON, or no body when nil
+func newJSONRequest(t *testing.T, method, target string, body interface{}) *http.Request {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("encode request body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+// testApplicantSeq numbers the placeholder emails of test applicants
+var testApplicantSeq int64
+
+// createTestApplicant inserts an applicant, filling the required fields the
+// caller leaves empty
+func createTestApplicant(t *testing.T, applicant models.Applicant) models.Applicant {
+	t.Helper()
+	if applicant.Name == "" {
+		applicant.Name = "Ada Lovelace"
+	}
+	if applicant.Email == "" {
+		applicant.Email = fmt.Sprintf("applicant%d@example.com", atomic.AddInt64(&testApplicantSeq, 1))
+	}
+	if applicant.Position == "" {
+		applicant.Position = "Engineer"
+	}
+	if applicant.Status == "" {
+		applicant.Status = utils.StatusPending
+	}
+	if applicant.StatusChangedAt.IsZero() {
+		applicant.StatusChangedAt = time.Now().UTC()
+	}
+	if err := database.DB.Create(&applicant).Error; err != nil {
+		t.Fatalf("create applicant: %v", err)
+	}
+	return applicant
+}
+
+// useTestRedis points the package Redis client at an in-memory server for the
+// duration of the test
+func useTestRedis(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	previous := rdb
+	rdb = client
+	t.Cleanup(func() {
+		rdb = previous
+		client.Close()
+	})
+	return server
+}
+
+// doRequest sends req through app and returns the response with its body read
+func doRequest(t *testing.T, app *fiber.App, req *http.Request) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s: %v", req.Method, req.URL, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body of %s %s: %v", req.Method, req.URL, err)
+	}
+	return resp, body
+}
+
+// decodeJSON unmarshals a response body, failing the test on invalid JSON
+func decodeJSON(t *testing.T, body []byte, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("decode %q: %v", body, err)
+	}
+}
+
+// defaultApplicantConfig is the applicant configuration Load returns without
+// any environment overrides
+func defaultApplicantConfig() config.ApplicantsConfig {
+	return config.ApplicantsConfig{
+		StatusSLA: map[string]time.Duration{
+			"pending":     72 * time.Hour,
+			"reviewed":    120 * time.Hour,
+			"interviewed": 168 * time.Hour,
+		},
+		MaxNotesLength:   5000,
+		MaxResumeLength:  20000,
+		MaxCommentLength: 2000,
+		MaxBatchSize:     100,
+		ExportChunkSize:  1000,
+		CacheMaxAge:      30 * time.Second,
+		ReviewLockTTL:    15 * time.Minute,
+		DefaultStatus:    utils.StatusPending,
+		DefaultCurrency:  "USD",
+		EmailReusePolicy: config.EmailReuseReject,
+		ReopenStatus:     utils.StatusReviewed,
+		ReopenRoles:      []string{"admin"},
+	}
+}
+
+// useApplicantConfig applies cfg for the duration of the test
+func useApplicantConfig(t *testing.T, cfg config.ApplicantsConfig) {
+	t.Helper()
+	if err := SetApplicantConfig(cfg); err != nil {
+		t.Fatalf("SetApplicantConfig: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := SetApplicantConfig(defaultApplicantConfig()); err != nil {
+			t.Fatalf("restore applicant config: %v", err)
+		}
+	})
+}
+
+// failingHook makes the Redis commands it names fail before they reach the server
+type failingHook struct {
+	commands map[string]bool
+}
+
+func (h failingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	if h.commands[cmd.Name()] {
+		return ctx, fmt.Errorf("injected %s failure", cmd.Name())
+	}
+	return ctx, nil
+}
+
+func (h failingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	return nil
+}
+
+func (h failingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	return ctx, nil
+}
+
+func (h failingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	return nil
+}
+
+// failRedisCommands makes the named commands, such as "get" or "set", fail on
+// the test Redis client
+func failRedisCommands(commands ...string) {
+	hook := failingHook{commands: map[string]bool{}}
+	for _, name := range commands {
+		hook.commands[name] = true
+	}
+	rdb.AddHook(hook)
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fieldHistoryEntry is one change to a single applicant field
+type fieldHistoryEntry struct {
+	At     time.Time   `json:"at"`
+	Action string      `json:"action"`
+	Actor  string      `json:"actor,omitempty"`
+	Reason string      `json:"reason,omitempty"`
+	Old    interface{} `json:"old"`
+	New    interface{} `json:"new"`
+}
+
+// GetApplicantFieldHistory returns the changes recorded in the audit log for
+// one field of an applicant, oldest first, e.g. for a status timeline
+func GetApplicantFieldHistory(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+	field := c.Params("field")
+	if !models.IsApplicantField(field) {
+		return c.Status(400).JSON(fiber.Map{"error": fmt.Sprintf("unknown field %q", field)})
+	}
+
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// History outlives deletion, so deleted applicants are included
+	var applicant models.Applicant
+	if err := database.DB.Unscoped().Select("id").First(&applicant, id).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+	}
+
+	var entries []models.AuditLog
+	if err := database.DB.
+		Where("applicant_id = ? AND changes -> ? IS NOT NULL", id, field).
+		Order("created_at ASC, id ASC").
+		Find(&entries).Error; err != nil {
+		log.Printf("Database error loading history of applicant %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load field history"})
+	}
+
+	history := make([]fieldHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		var changes map[string]models.FieldChange
+		if err := json.Unmarshal(entry.Changes, &changes); err != nil {
+			log.Printf("Skipping unreadable audit entry %d: %v", entry.ID, err)
+			continue
+		}
+		change := changes[field]
+		history = append(history, fieldHistoryEntry{
+			At:     entry.CreatedAt.In(loc),
+			Action: entry.Action,
+			Actor:  entry.Actor,
+			Reason: entry.Reason,
+			Old:    change.Old,
+			New:    change.New,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"applicant_id": id,
+		"field":        field,
+		"history":      history,
+	})
+}
+
+// GetApplicantStatusHistory returns every status change of an applicant,
+// oldest first, with who made it
+func GetApplicantStatusHistory(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	loc, err := parseTimezone(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// History outlives deletion, so deleted applicants are included
+	var applicant models.Applicant
+	if err := database.DB.Unscoped().Select("id").First(&applicant, id).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+	}
+
+	var history []models.StatusHistory
+	if err := database.DB.
+		Where("applicant_id = ?", id).
+		Order("changed_at ASC, id ASC").
+		Find(&history).Error; err != nil {
+		log.Printf("Database error loading status history of applicant %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load status history"})
+	}
+	for i := range history {
+		history[i].ChangedAt = history[i].ChangedAt.In(loc)
+	}
+
+	return c.JSON(fiber.Map{
+		"applicant_id": id,
+		"history":      history,
+	})
+}
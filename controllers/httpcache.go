@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setCacheHeaders marks a single-record response as cacheable for
+// applicantMaxAge and stamps it with its last modification time. It reports
+// whether the client's If-Modified-Since copy is still current, in which case
+// the caller should answer 304 without a body.
+func setCacheHeaders(c *fiber.Ctx, lastModified time.Time) bool {
+	// HTTP dates have whole-second precision
+	lastModified = lastModified.UTC().Truncate(time.Second)
+
+	if applicantMaxAge > 0 {
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(applicantMaxAge.Seconds())))
+	} else {
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+	}
+	c.Set(fiber.HeaderLastModified, lastModified.Format(http.TimeFormat))
+
+	since, err := http.ParseTime(c.Get(fiber.HeaderIfModifiedSince))
+	return err == nil && !lastModified.After(since)
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/models"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetApplicantCacheHeaders(t *testing.T) {
+	tests := []struct {
+		maxAge time.Duration
+		want   string
+	}{
+		{30 * time.Second, "public, max-age=30"},
+		{2 * time.Minute, "public, max-age=120"},
+		{0, "no-cache"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.maxAge.String(), func(t *testing.T) {
+			useTestBackends(t)
+			cfg := defaultApplicantConfig()
+			cfg.CacheMaxAge = tt.maxAge
+			useApplicantConfig(t, cfg)
+			updated := time.Date(2024, 3, 1, 9, 30, 15, 500, time.UTC)
+			applicant := createTestApplicant(t, models.Applicant{UpdatedAt: updated})
+
+			req := newJSONRequest(t, "GET", fmt.Sprintf("/applicants/%d", applicant.ID), nil)
+			resp, body := doRequest(t, newApplicantTestApp(), req)
+			if resp.StatusCode != 200 {
+				t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+			}
+			if got := resp.Header.Get("Cache-Control"); got != tt.want {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.want)
+			}
+			if got := resp.Header.Get("Last-Modified"); got != "Fri, 01 Mar 2024 09:30:15 GMT" {
+				t.Errorf("Last-Modified = %q, want the applicant's updated_at", got)
+			}
+		})
+	}
+}
+
+func TestGetApplicantIfModifiedSince(t *testing.T) {
+	useTestBackends(t)
+	updated := time.Date(2024, 3, 1, 9, 30, 15, 0, time.UTC)
+	applicant := createTestApplicant(t, models.Applicant{UpdatedAt: updated})
+	app := newApplicantTestApp()
+
+	tests := []struct {
+		name  string
+		since time.Time
+		want  int
+	}{
+		{"same time", updated, 304},
+		{"later", updated.Add(time.Hour), 304},
+		{"earlier", updated.Add(-time.Second), 200},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newJSONRequest(t, "GET", fmt.Sprintf("/applicants/%d", applicant.ID), nil)
+			req.Header.Set("If-Modified-Since", tt.since.Format(http.TimeFormat))
+			resp, body := doRequest(t, app, req)
+			if resp.StatusCode != tt.want {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if tt.want == 304 && len(body) != 0 {
+				t.Errorf("304 body = %q, want empty", body)
+			}
+			if resp.Header.Get("Last-Modified") == "" {
+				t.Error("Last-Modified missing")
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"job-tracker/events"
+	"job-tracker/jobs"
+	"job-tracker/models"
+	"job-tracker/notifications"
+	"job-tracker/utils"
+	"log"
+)
+
+// jobQueue runs the work handlers hand off to the background
+var jobQueue jobs.Queue
+
+// dispatcher delivers applicant events to their subscribers
+var dispatcher *events.Dispatcher
+
+// SetJobQueue sets the queue handlers use for background work
+func SetJobQueue(queue jobs.Queue) {
+	jobQueue = queue
+}
+
+// SetEventDispatcher sets the dispatcher handlers publish applicant events to
+func SetEventDispatcher(d *events.Dispatcher) {
+	dispatcher = d
+}
+
+// publishEvent publishes an applicant event, if a dispatcher is set
+func publishEvent(eventType string, applicant models.Applicant, actor string, changes map[string]models.FieldChange) {
+	if dispatcher == nil {
+		return
+	}
+	event := events.Event{
+		Type:        eventType,
+		ApplicantID: applicant.ID,
+		Actor:       actor,
+		Changes:     changes,
+	}
+	if eventType != events.ApplicantDeleted {
+		event.Applicant = &applicant
+	}
+	dispatcher.Publish(event)
+}
+
+// notifyStatusChange queues the status change email for an applicant and
+// publishes the status change event
+func notifyStatusChange(applicant models.Applicant, previousStatus utils.Status, actor string) {
+	publishEvent(events.ApplicantStatusChanged, applicant, actor, map[string]models.FieldChange{
+		"status": {Old: previousStatus, New: applicant.Status},
+	})
+
+	job := jobs.Job{
+		Name: fmt.Sprintf("status-email:%d", applicant.ID),
+		Run: func(ctx context.Context) error {
+			return notifications.StatusChanged(applicant, string(previousStatus))
+		},
+	}
+	if err := jobQueue.Enqueue(job); err != nil {
+		log.Printf("Failed to queue status email for applicant %d: %v", applicant.ID, err)
+	}
+}
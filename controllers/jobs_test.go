@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"job-tracker/events"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"testing"
+)
+
+// useTestEvents publishes applicant events through queue for the duration of
+// the test and returns the events delivered so far
+func useTestEvents(t *testing.T, queue *testQueue) *[]events.Event {
+	t.Helper()
+	var published []events.Event
+	d := events.NewDispatcher(queue)
+	if err := d.Subscribe("test", nil, func(ctx context.Context, event events.Event) error {
+		published = append(published, event)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	previous := dispatcher
+	SetEventDispatcher(d)
+	t.Cleanup(func() { SetEventDispatcher(previous) })
+	return &published
+}
+
+// eventTypes returns the types of events in the order they were published
+func eventTypes(published []events.Event) []string {
+	types := make([]string, len(published))
+	for i, event := range published {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func TestApplicantActionsPublishEvents(t *testing.T) {
+	_, _, queue := useTestBackends(t)
+	published := useTestEvents(t, queue)
+	app := newApplicantTestApp()
+	applicant := createTestApplicant(t, models.Applicant{Email: "ada@example.com"})
+	other := createTestApplicant(t, models.Applicant{})
+	path := fmt.Sprintf("/applicants/%d", applicant.ID)
+
+	steps := []struct {
+		name   string
+		method string
+		target string
+		role   string
+		body   interface{}
+		want   []string
+		// change is a field every published event's delta must include
+		change string
+	}{
+		{"create", "POST", "/applicants", "", map[string]string{"name": "Grace", "email": "grace@example.com", "position": "Engineer"},
+			[]string{events.ApplicantCreated}, ""},
+		{"clone", "POST", path + "/clone", "", map[string]string{"email": "ada.copy@example.com"},
+			[]string{events.ApplicantCreated}, ""},
+		{"patch", "PATCH", path, "", map[string]string{"name": "Ada King"},
+			[]string{events.ApplicantUpdated}, "name"},
+		{"put with status", "PUT", path, "", map[string]string{"status": "reviewed"},
+			[]string{events.ApplicantUpdated, events.ApplicantStatusChanged}, "status"},
+		{"put without changes", "PUT", path, "", map[string]string{"name": "Ada King"},
+			[]string{}, ""},
+		{"transition", "POST", path + "/transition", "", map[string]string{"to": "interviewed"},
+			[]string{events.ApplicantStatusChanged}, "status"},
+		{"tag", "POST", path + "/tags", "", map[string][]string{"tags": {"referral"}},
+			[]string{events.ApplicantTagged}, "tags"},
+		{"tag again", "POST", path + "/tags", "", map[string][]string{"tags": {"referral"}},
+			[]string{}, ""},
+		{"untag", "DELETE", path + "/tags/referral", "", nil,
+			[]string{events.ApplicantTagged}, "tags"},
+		{"mark reviewed", "POST", "/applicants/mark-reviewed", "", map[string][]uint{"ids": {other.ID}},
+			[]string{events.ApplicantStatusChanged}, "status"},
+		{"delete", "DELETE", path, "admin", nil,
+			[]string{events.ApplicantDeleted}, ""},
+		{"batch delete", "DELETE", "/applicants/batch", "admin", map[string][]uint{"ids": {other.ID, 9999}},
+			[]string{events.ApplicantDeleted}, ""},
+	}
+	for _, step := range steps {
+		*published = nil
+		req := newJSONRequest(t, step.method, step.target, step.body)
+		if step.role != "" {
+			req.Header.Set(testRoleHeader, step.role)
+		}
+		resp, body := doRequest(t, app, req)
+		if resp.StatusCode >= 300 {
+			t.Fatalf("%s: status = %d, body %s", step.name, resp.StatusCode, body)
+		}
+
+		got := eventTypes(*published)
+		if fmt.Sprint(got) != fmt.Sprint(step.want) {
+			t.Errorf("%s: published %v, want %v", step.name, got, step.want)
+			continue
+		}
+		if step.change == "" {
+			continue
+		}
+		for _, event := range *published {
+			if _, ok := event.Changes[step.change]; !ok {
+				t.Errorf("%s: %s changes = %v, want %s", step.name, event.Type, event.Changes, step.change)
+			}
+		}
+	}
+}
+
+func TestEventPayloads(t *testing.T) {
+	_, _, queue := useTestBackends(t)
+	published := useTestEvents(t, queue)
+	app := newApplicantTestApp()
+	applicant := createTestApplicant(t, models.Applicant{})
+	path := fmt.Sprintf("/applicants/%d", applicant.ID)
+
+	doRequest(t, app, newJSONRequest(t, "POST", path+"/transition", map[string]string{"to": "reviewed"}))
+	if len(*published) != 1 {
+		t.Fatalf("published %v, want one status change", eventTypes(*published))
+	}
+	status := (*published)[0]
+	change := status.Changes["status"]
+	if fmt.Sprint(change.Old) != "pending" || fmt.Sprint(change.New) != "reviewed" {
+		t.Errorf("status delta = %v -> %v, want pending -> reviewed", change.Old, change.New)
+	}
+	if status.ApplicantID != applicant.ID || status.Actor != "recruiter@example.com" || status.Applicant == nil ||
+		status.Applicant.Status != utils.StatusReviewed {
+		t.Errorf("status event = %+v, want the applicant after the change and its actor", status)
+	}
+
+	*published = nil
+	doRequest(t, app, newJSONRequest(t, "POST", path+"/tags", map[string][]string{"tags": {"Referral", "remote"}}))
+	if len(*published) != 1 {
+		t.Fatalf("published %v, want one tag event", eventTypes(*published))
+	}
+	tags := (*published)[0].Changes["tags"]
+	newTags := fmt.Sprint(tags.New)
+	if fmt.Sprint(tags.Old) != "[]" || newTags != fmt.Sprint([]string{"referral", "remote"}) {
+		t.Errorf("tags delta = %v -> %v, want [] -> [referral remote]", tags.Old, tags.New)
+	}
+
+	*published = nil
+	req := newJSONRequest(t, "DELETE", path, nil)
+	req.Header.Set(testRoleHeader, "admin")
+	doRequest(t, app, req)
+	if len(*published) != 1 || (*published)[0].Applicant != nil || (*published)[0].ApplicantID != applicant.ID {
+		t.Errorf("delete published %+v, want one event with only the applicant id", *published)
+	}
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"errors"
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// markReviewedResult is the outcome of MarkApplicantsReviewed for one id
+type markReviewedResult struct {
+	ID     int64  `json:"id"`
+	Result string `json:"result"`
+	// Status is the unchanged current status of a skipped applicant
+	Status utils.Status `json:"status,omitempty"`
+}
+
+// MarkApplicantsReviewed moves every listed applicant that is pending to
+// reviewed, for the common list view action. Each id goes through the same
+// locked, audited status change as /transition, and is reported as reviewed,
+// skipped (not pending), not_found or invalid.
+func MarkApplicantsReviewed(c *fiber.Ctx) error {
+	ids, err := parseBatchIDs(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	actor := currentUser(c)
+	results := make([]markReviewedResult, 0, len(ids))
+	reported := make(map[int64]bool, len(ids))
+	var reviewed []uint
+	for _, id := range ids {
+		if reported[id] {
+			continue
+		}
+		reported[id] = true
+
+		if id <= 0 {
+			results = append(results, markReviewedResult{ID: id, Result: "invalid"})
+			continue
+		}
+
+		from, err := changeStatus(uint(id), utils.StatusReviewed, "mark-reviewed", actor, "", func(from utils.Status) bool {
+			return from == utils.StatusPending
+		})
+		switch {
+		case err == nil:
+			results = append(results, markReviewedResult{ID: id, Result: "reviewed"})
+			reviewed = append(reviewed, uint(id))
+		case errors.Is(err, errIllegalTransition):
+			results = append(results, markReviewedResult{ID: id, Result: "skipped", Status: from})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			results = append(results, markReviewedResult{ID: id, Result: "not_found"})
+		default:
+			log.Printf("Database error marking applicant %d reviewed: %v", id, err)
+			return c.Status(500).JSON(fiber.Map{
+				"error":   "Failed to mark applicants reviewed",
+				"results": results,
+			})
+		}
+	}
+
+	if len(reviewed) > 0 {
+		invalidateApplicantListCache()
+
+		var applicants []models.Applicant
+		if err := database.DB.Scopes(withTags).Where("id IN ?", reviewed).Find(&applicants).Error; err != nil {
+			log.Printf("Database error loading reviewed applicants for notification: %v", err)
+		}
+		for _, applicant := range applicants {
+			notifyStatusChange(applicant, "pending", actor)
+		}
+	}
+	log.Printf("Marked %d of %d applicants reviewed", len(reviewed), len(results))
+
+	return c.JSON(fiber.Map{
+		"reviewed": len(reviewed),
+		"results":  results,
+	})
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"testing"
+)
+
+func TestMarkApplicantsReviewed(t *testing.T) {
+	_, _, queue := useTestBackends(t)
+	pending := createTestApplicant(t, models.Applicant{Status: utils.StatusPending})
+	reviewed := createTestApplicant(t, models.Applicant{Status: utils.StatusReviewed})
+	hired := createTestApplicant(t, models.Applicant{Status: utils.StatusHired})
+	rejected := createTestApplicant(t, models.Applicant{Status: utils.StatusRejected})
+	deleted := createTestApplicant(t, models.Applicant{Status: utils.StatusPending})
+	if err := database.DB.Delete(&deleted).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	ids := []int64{int64(pending.ID), int64(reviewed.ID), int64(hired.ID), int64(rejected.ID), int64(deleted.ID), 9999, 0, int64(pending.ID)}
+	req := newJSONRequest(t, "POST", "/applicants/mark-reviewed", map[string][]int64{"ids": ids})
+	resp, body := doRequest(t, newApplicantTestApp(), req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, want 200: %s", resp.StatusCode, body)
+	}
+	var got struct {
+		Reviewed int                  `json:"reviewed"`
+		Results  []markReviewedResult `json:"results"`
+	}
+	decodeJSON(t, body, &got)
+	want := []markReviewedResult{
+		{ID: int64(pending.ID), Result: "reviewed"},
+		{ID: int64(reviewed.ID), Result: "skipped", Status: utils.StatusReviewed},
+		{ID: int64(hired.ID), Result: "skipped", Status: utils.StatusHired},
+		{ID: int64(rejected.ID), Result: "skipped", Status: utils.StatusRejected},
+		{ID: int64(deleted.ID), Result: "not_found"},
+		{ID: 9999, Result: "not_found"},
+		{ID: 0, Result: "invalid"},
+	}
+	if got.Reviewed != 1 || fmt.Sprint(got.Results) != fmt.Sprint(want) {
+		t.Errorf("reviewed %d, results %+v\nwant 1, %+v", got.Reviewed, got.Results, want)
+	}
+
+	statuses := map[uint]utils.Status{
+		pending.ID:  utils.StatusReviewed,
+		reviewed.ID: utils.StatusReviewed,
+		hired.ID:    utils.StatusHired,
+		rejected.ID: utils.StatusRejected,
+	}
+	for id, want := range statuses {
+		var stored models.Applicant
+		if err := database.DB.First(&stored, id).Error; err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != want {
+			t.Errorf("applicant %d status = %q, want %q", id, stored.Status, want)
+		}
+	}
+
+	// Only the applicant that changed is audited, recorded and notified
+	for _, id := range []uint{reviewed.ID, hired.ID, rejected.ID} {
+		if actions := auditActions(t, id); len(actions) != 0 {
+			t.Errorf("skipped applicant %d audited %v", id, actions)
+		}
+	}
+	if actions := auditActions(t, pending.ID); len(actions) != 1 || actions[0] != "mark-reviewed" {
+		t.Errorf("audit actions = %v, want [mark-reviewed]", actions)
+	}
+	var history []models.StatusHistory
+	database.DB.Find(&history)
+	if len(history) != 1 || history[0].ApplicantID != pending.ID || history[0].FromStatus != utils.StatusPending {
+		t.Errorf("status history = %+v, want one pending to reviewed entry", history)
+	}
+	if want := fmt.Sprintf("status-email:%d", pending.ID); fmt.Sprint(queue.names) != fmt.Sprint([]string{want}) {
+		t.Errorf("queued jobs = %v, want [%s]", queue.names, want)
+	}
+}
+
+func TestMarkApplicantsReviewedLimits(t *testing.T) {
+	useTestBackends(t)
+	cfg := defaultApplicantConfig()
+	cfg.MaxBatchSize = 2
+	useApplicantConfig(t, cfg)
+	app := newApplicantTestApp()
+
+	for _, ids := range [][]int64{{}, {1, 2, 3}} {
+		req := newJSONRequest(t, "POST", "/applicants/mark-reviewed", map[string][]int64{"ids": ids})
+		if resp, body := doRequest(t, app, req); resp.StatusCode != 400 {
+			t.Errorf("%d ids: status = %d, want 400, body %s", len(ids), resp.StatusCode, body)
+		}
+	}
+}
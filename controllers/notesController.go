@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// AppendNoteRequest is the body accepted by AppendApplicantNote
+type AppendNoteRequest struct {
+	Text string `json:"text"`
+}
+
+// AppendApplicantNote adds a timestamped line to an applicant's notes. The
+// concatenation happens in a single UPDATE so concurrent appends from several
+// recruiters never overwrite each other, unlike a read-modify-write through PUT.
+func AppendApplicantNote(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var req AppendNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	req.Text = utils.SanitizeString(req.Text)
+	if req.Text == "" {
+		return c.Status(422).JSON(fiber.Map{"error": "text must not be empty", "field": "text"})
+	}
+
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	line := fmt.Sprintf("[%s] %s", timestamp, req.Text)
+	if author := currentUser(c); author != "" {
+		line = fmt.Sprintf("[%s] %s: %s", timestamp, author, req.Text)
+	}
+
+	// The length guard is evaluated against the current value in the same
+	// statement, so the limit holds even under concurrent appends
+	result := database.DB.Model(&models.Applicant{}).
+		Where("id = ?", id).
+		Where("COALESCE(char_length(notes), 0) + ? <= ?", utf8.RuneCountInString(line)+1, maxNotesLength).
+		Update("notes", gorm.Expr("CASE WHEN COALESCE(notes, '') = '' THEN ? ELSE notes || ? END", line, "\n"+line))
+	if result.Error != nil {
+		log.Printf("Database error appending note to applicant %d: %v", id, result.Error)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to append note"})
+	}
+
+	var applicant models.Applicant
+	if err := database.DB.Scopes(withTags).First(&applicant, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+		}
+		log.Printf("Database error reloading applicant %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load updated applicant"})
+	}
+
+	// The applicant exists, so nothing was updated because the notes are full
+	if result.RowsAffected == 0 {
+		return c.Status(422).JSON(fiber.Map{
+			"error": fmt.Sprintf("notes must be at most %d characters", maxNotesLength),
+			"field": "notes",
+		})
+	}
+
+	invalidateApplicantListCache()
+	invalidateApplicantCache(id)
+	return c.JSON(applicant)
+}
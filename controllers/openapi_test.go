@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"job-tracker/apidog"
+	"job-tracker/database"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gofiber/fiber/v2"
+)
+
+// specServer is the server URL from the spec that test requests are sent to
+const specServer = "http://localhost:3000"
+
+// loadServedSpec fetches the OpenAPI document from /openapi.json and builds a
+// router over it. Object schemas are made strict, so a response field the
+// spec doesn't list, such as a renamed one, fails validation.
+func loadServedSpec(t *testing.T) routers.Router {
+	t.Helper()
+	app := fiber.New()
+	app.Get("/openapi.json", apidog.SpecHandler)
+	resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/openapi.json", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("GET /openapi.json: status %d", resp.StatusCode)
+	}
+
+	doc, err := openapi3.NewLoader().LoadFromData(body)
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		t.Fatalf("spec is invalid: %v", err)
+	}
+	for _, schema := range doc.Components.Schemas {
+		flattenAllOf(schema.Value)
+	}
+	seen := map[*openapi3.Schema]bool{}
+	for _, schema := range doc.Components.Schemas {
+		forbidUnlistedProperties(schema.Value, seen)
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatalf("route spec: %v", err)
+	}
+	return router
+}
+
+// flattenAllOf merges the properties of an allOf schema into the schema
+// itself, so that making its parts strict doesn't reject the fields the other
+// parts add. The parts are shared with other schemas and left unchanged.
+func flattenAllOf(schema *openapi3.Schema) {
+	if len(schema.AllOf) == 0 {
+		return
+	}
+	properties := openapi3.Schemas{}
+	for name, property := range schema.Properties {
+		properties[name] = property
+	}
+	required := append([]string{}, schema.Required...)
+	for _, part := range schema.AllOf {
+		for name, property := range part.Value.Properties {
+			properties[name] = property
+		}
+		required = append(required, part.Value.Required...)
+	}
+	schema.Type = openapi3.TypeObject
+	schema.Properties = properties
+	schema.Required = required
+	schema.AllOf = nil
+}
+
+// forbidUnlistedProperties sets additionalProperties to false on every object
+// schema that lists its properties
+func forbidUnlistedProperties(schema *openapi3.Schema, seen map[*openapi3.Schema]bool) {
+	if schema == nil || seen[schema] {
+		return
+	}
+	seen[schema] = true
+	if len(schema.Properties) > 0 && schema.AdditionalProperties.Schema == nil {
+		forbid := false
+		schema.AdditionalProperties.Has = &forbid
+	}
+	for _, property := range schema.Properties {
+		forbidUnlistedProperties(property.Value, seen)
+	}
+	if schema.Items != nil {
+		forbidUnlistedProperties(schema.Items.Value, seen)
+	}
+	for _, group := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, ref := range group {
+			forbidUnlistedProperties(ref.Value, seen)
+		}
+	}
+}
+
+// contractCase is one request whose response must match the spec
+type contractCase struct {
+	name   string
+	method string
+	path   string
+	body   interface{}
+	role   string
+	status int
+}
+
+// checkContract sends each case through app and validates the response
+// against the spec operation it is routed to
+func checkContract(t *testing.T, router routers.Router, app *fiber.App, cases []contractCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newJSONRequest(t, tc.method, specServer+tc.path, tc.body)
+			if tc.role != "" {
+				req.Header.Set(testRoleHeader, tc.role)
+			}
+			route, pathParams, err := router.FindRoute(req)
+			if err != nil {
+				t.Fatalf("%s %s is not in the spec: %v", tc.method, tc.path, err)
+			}
+
+			resp, body := doRequest(t, app, req)
+			if resp.StatusCode != tc.status {
+				t.Fatalf("status = %d, want %d, body %s", resp.StatusCode, tc.status, body)
+			}
+
+			input := &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: &openapi3filter.RequestValidationInput{
+					Request:    req,
+					PathParams: pathParams,
+					Route:      route,
+				},
+				Status: resp.StatusCode,
+				Header: resp.Header,
+				Body:   io.NopCloser(bytes.NewReader(body)),
+				Options: &openapi3filter.Options{
+					IncludeResponseStatus: true,
+					MultiError:            true,
+				},
+			}
+			if err := openapi3filter.ValidateResponse(context.Background(), input); err != nil {
+				t.Errorf("response does not match the spec: %v\nbody: %s", err, body)
+			}
+		})
+	}
+}
+
+func TestApplicantResponsesMatchSpec(t *testing.T) {
+	router := loadServedSpec(t)
+	useTestBackends(t)
+	app := newApplicantTestApp()
+	app.Get("/applicants/:id/comments", GetComments)
+	app.Post("/applicants/:id/comments", middleware.ValidateBody(CommentSchema), CreateComment)
+	app.Delete("/applicants/:id/comments/:commentId", DeleteComment)
+
+	salary := int64(90000)
+	existing := createTestApplicant(t, models.Applicant{
+		Email: "grace@example.com", Status: utils.StatusReviewed, Phone: "+14155550100",
+		LinkedInURL: "https://www.linkedin.com/in/grace", ExpectedSalary: &salary, SalaryCurrency: "USD",
+	})
+	overdue := createTestApplicant(t, models.Applicant{})
+	database.DB.Model(&overdue).Update("status_changed_at", overdue.StatusChangedAt.AddDate(0, 0, -30))
+	deleted := createTestApplicant(t, models.Applicant{})
+	comment := models.Comment{ApplicantID: existing.ID, AuthorID: "recruiter@example.com", Body: "Strong portfolio"}
+	if err := database.DB.Create(&comment).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	id := fmt.Sprint(existing.ID)
+	checkContract(t, router, app, []contractCase{
+		{"list", "GET", "/applicants?page=1&limit=10", nil, "", 200},
+		{"list filtered", "GET", "/applicants?status=pending,reviewed&sort=name&order=asc", nil, "", 200},
+		{"list cursor", "GET", "/applicants?cursor=0&limit=1", nil, "", 200},
+		{"list bad limit", "GET", "/applicants?limit=500", nil, "", 400},
+		{"overdue", "GET", "/applicants/overdue", nil, "", 200},
+		{"get", "GET", "/applicants/" + id, nil, "", 200},
+		{"get with tz", "GET", "/applicants/" + id + "?tz=Europe/Paris", nil, "", 200},
+		{"get missing", "GET", "/applicants/999999", nil, "", 404},
+		{"get invalid id", "GET", "/applicants/abc", nil, "", 400},
+		{"create", "POST", "/applicants", map[string]interface{}{
+			"name": "Alan Turing", "email": "alan@example.com", "position": "Researcher",
+		}, "", 201},
+		{"create with changes", "POST", "/applicants?include_changes=true", map[string]interface{}{
+			"name": "Katherine Johnson", "email": "katherine@example.com", "position": "Analyst",
+		}, "", 201},
+		{"create duplicate", "POST", "/applicants", map[string]interface{}{
+			"name": "Grace Hopper", "email": "grace@example.com", "position": "Engineer",
+		}, "", 409},
+		{"create invalid", "POST", "/applicants", map[string]interface{}{"name": 5}, "", 422},
+		{"update", "PUT", "/applicants/" + id, map[string]interface{}{"position": "Staff Engineer"}, "", 200},
+		{"update with changes", "PUT", "/applicants/" + id + "?include_changes=true", map[string]interface{}{"notes": "Call back"}, "", 200},
+		{"update illegal status", "PUT", "/applicants/" + id, map[string]interface{}{"status": "pending"}, "", 422},
+		{"patch", "PATCH", "/applicants/" + id, map[string]interface{}{"phone_extension": "12"}, "", 200},
+		{"add tags", "POST", "/applicants/" + id + "/tags", map[string]interface{}{"tags": []string{"Referral", "remote"}}, "", 200},
+		{"remove tag", "DELETE", "/applicants/" + id + "/tags/remote", nil, "", 200},
+		{"remove missing tag", "DELETE", "/applicants/" + id + "/tags/onsite", nil, "", 404},
+		{"list comments", "GET", "/applicants/" + id + "/comments", nil, "", 200},
+		{"add comment", "POST", "/applicants/" + id + "/comments", map[string]interface{}{"body": "Booked onsite"}, "", 201},
+		{"delete comment", "DELETE", fmt.Sprintf("/applicants/%d/comments/%d", existing.ID, comment.ID), nil, "", 200},
+		{"delete as recruiter", "DELETE", fmt.Sprintf("/applicants/%d", deleted.ID), nil, "", 403},
+		{"delete", "DELETE", fmt.Sprintf("/applicants/%d", deleted.ID), nil, "admin", 200},
+		{"changes feed", "GET", "/applicants?updated_since=2000-01-01T00:00:00Z&include_deleted=true", nil, "", 200},
+	})
+}
@@ -0,0 +1,221 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// patchColumns maps each field PATCH accepts, by its JSON name, to its column
+var patchColumns = map[string]string{
+	"name":            "name",
+	"email":           "email",
+	"position":        "position",
+	"status":          "status",
+	"phone":           "phone",
+	"phone_extension": "phone_extension",
+	"resume":          "resume",
+	"notes":           "notes",
+	"linkedin_url":    "linked_in_url",
+	"portfolio_url":   "portfolio_url",
+	"expected_salary": "expected_salary",
+	"salary_currency": "salary_currency",
+}
+
+// PatchApplicant applies a partial update. Unlike PUT, only the fields present
+// in the body are written, so a field can be cleared by sending "" or null.
+// Unknown fields return 400 listing all of them; fields UPDATABLE_FIELDS does
+// not allow return 422, as with PUT.
+func PatchApplicant(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(c.Body(), &body); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	if len(body) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "body must contain at least one field"})
+	}
+
+	var unknown []string
+	for name := range body {
+		if _, ok := patchColumns[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return c.Status(400).JSON(fiber.Map{
+			"error":  "unknown fields: " + strings.Join(unknown, ", "),
+			"fields": unknown,
+		})
+	}
+	if field, found := disallowedUpdateField(c.Body()); found {
+		return c.Status(422).JSON(fiber.Map{
+			"error": fmt.Sprintf("%s may not be updated", field),
+			"field": field,
+		})
+	}
+
+	var applicant models.Applicant
+	if err := database.DB.First(&applicant, id).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+	}
+
+	// Decode the body over a copy of the stored applicant so untouched fields
+	// keep their values. null on a text field clears it, like "".
+	for name, value := range body {
+		if value == nil && name != "expected_salary" {
+			body[name] = ""
+		}
+	}
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	patched := applicant
+	if applicant.ExpectedSalary != nil {
+		// Decoding a number writes through the pointer, which must not be shared
+		salary := *applicant.ExpectedSalary
+		patched.ExpectedSalary = &salary
+	}
+	if err := json.Unmarshal(encoded, &patched); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	columns := make([]string, 0, len(body)+3)
+	for name := range body {
+		columns = append(columns, patchColumns[name])
+	}
+	sent := func(names ...string) bool {
+		for _, name := range names {
+			if _, ok := body[name]; ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	patched.Name = utils.SanitizeString(patched.Name)
+	patched.Email = strings.ToLower(utils.SanitizeString(patched.Email))
+	patched.Position = utils.SanitizeString(patched.Position)
+	patched.Status = cleanStatus(patched.Status)
+
+	// The required fields and status can be changed but not cleared
+	for _, name := range requiredFields {
+		if sent(name) && isBlankField(&patched, name) {
+			return c.Status(422).JSON(fiber.Map{"error": name + " must not be empty", "field": name})
+		}
+	}
+	if sent("status") && patched.Status == "" {
+		return c.Status(422).JSON(fiber.Map{"error": "status must not be empty", "field": "status"})
+	}
+
+	if sent("email") && patched.Email != applicant.Email {
+		if !utils.ValidateEmail(patched.Email) {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid email format"})
+		}
+		patched.CanonicalEmail = canonicalEmail(patched.Email)
+		columns = append(columns, "canonical_email")
+		taken, err := emailTakenByOther(patched.Email, applicant.ID)
+		if err != nil {
+			log.Printf("Database error checking email: %v", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to update applicant"})
+		}
+		if taken {
+			return c.Status(409).JSON(fiber.Map{"error": "Email already exists"})
+		}
+	}
+
+	if sent("status") {
+		if !patched.Status.Valid() {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid status value"})
+		}
+		if message := illegalTransition(applicant.Status, patched.Status); message != "" {
+			return c.Status(422).JSON(fiber.Map{"error": message, "field": "status"})
+		}
+	}
+
+	// An extension written into the phone moves to phone_extension, so both
+	// columns are written when either is sent
+	if sent("phone", "phone_extension") {
+		if _, message, ok := normalizePhone(&patched); !ok {
+			return c.Status(400).JSON(fiber.Map{"error": message})
+		}
+		columns = append(columns, "phone", "phone_extension")
+	}
+
+	if errs := validateTextLengths(&patched); len(errs) > 0 {
+		return respondFieldErrors(c, errs)
+	}
+
+	if sent("position") {
+		if err := utils.ValidatePosition(patched.Position); err != nil {
+			return c.Status(422).JSON(fiber.Map{"error": "position " + err.Error(), "field": "position"})
+		}
+	}
+
+	// A salary without a currency falls back to DEFAULT_CURRENCY
+	if sent("expected_salary", "salary_currency") {
+		if field, message, ok := normalizeSalary(&patched); !ok {
+			return c.Status(422).JSON(fiber.Map{"error": message, "field": field})
+		}
+		columns = append(columns, "expected_salary", "salary_currency")
+	}
+
+	if sent("linkedin_url", "portfolio_url") {
+		patched.LinkedInURL = utils.SanitizeString(patched.LinkedInURL)
+		patched.PortfolioURL = utils.SanitizeString(patched.PortfolioURL)
+		if errs := validateURLs(&patched); len(errs) > 0 {
+			return respondFieldErrors(c, errs)
+		}
+	}
+
+	if patched.Status != applicant.Status {
+		patched.StatusChangedAt = time.Now().UTC()
+		columns = append(columns, "status_changed_at")
+	}
+
+	return saveApplicantUpdate(c, applicant, func(tx *gorm.DB, applicant *models.Applicant) error {
+		return tx.Model(applicant).Select(columns).Updates(&patched).Error
+	})
+}
+
+// isBlankField reports whether a field REQUIRED_FIELDS may name is empty
+func isBlankField(applicant *models.Applicant, name string) bool {
+	switch name {
+	case "name":
+		return applicant.Name == ""
+	case "email":
+		return applicant.Email == ""
+	case "position":
+		return applicant.Position == ""
+	case "phone":
+		return applicant.Phone == ""
+	case "resume":
+		return applicant.Resume == ""
+	case "notes":
+		return applicant.Notes == ""
+	case "linkedin_url":
+		return applicant.LinkedInURL == ""
+	case "portfolio_url":
+		return applicant.PortfolioURL == ""
+	case "expected_salary":
+		return applicant.ExpectedSalary == nil
+	case "salary_currency":
+		return applicant.SalaryCurrency == ""
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/pii"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// ReencryptPII rewrites phone numbers and resumes not yet stored the way new
+// writes are: encrypted with the active key, or in plaintext once encryption
+// is turned off. It runs at startup, after enabling encryption or rotating
+// the key, in batches so large tables aren't locked at once.
+func ReencryptPII() {
+	query := database.DB.Unscoped().Model(&models.Applicant{})
+	if prefix := pii.ActivePrefix(); prefix != "" {
+		query = query.Where("(phone <> '' AND left(phone, ?) <> ?) OR (resume <> '' AND left(resume, ?) <> ?)",
+			len(prefix), prefix, len(prefix), prefix)
+	} else {
+		query = query.Where("left(phone, ?) = ? OR left(resume, ?) = ?",
+			len(pii.Marker), pii.Marker, len(pii.Marker), pii.Marker)
+	}
+
+	total := 0
+	var lastID uint
+	for {
+		var applicants []models.Applicant
+		// Scanning decrypts with whichever key each value was written with
+		if err := query.Session(&gorm.Session{}).Select("id", "phone", "resume").
+			Where("id > ?", lastID).Order("id ASC").
+			Limit(100).Find(&applicants).Error; err != nil {
+			log.Printf("Warning: PII re-encryption stopped: %v", err)
+			return
+		}
+		if len(applicants) == 0 {
+			break
+		}
+
+		for _, applicant := range applicants {
+			if err := database.DB.Unscoped().Model(&applicant).UpdateColumns(map[string]interface{}{
+				"phone":  applicant.Phone,
+				"resume": applicant.Resume,
+			}).Error; err != nil {
+				log.Printf("Warning: PII re-encryption stopped: %v", err)
+				return
+			}
+		}
+		total += len(applicants)
+		lastID = applicants[len(applicants)-1].ID
+	}
+	if total > 0 {
+		log.Printf("Re-encrypted personal data of %d applicants", total)
+	}
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"job-tracker/utils"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// positionSuggestion is an existing position value and how similar it is to the query
+type positionSuggestion struct {
+	Position string  `json:"position"`
+	Score    float64 `json:"score"`
+}
+
+// SuggestPositions returns existing position titles similar to `q`, best match
+// first, using pg_trgm similarity so recruiters reuse consistent titles
+func SuggestPositions(c *fiber.Ctx) error {
+	q := utils.SanitizeString(c.Query("q"))
+	if len([]rune(q)) < 2 {
+		return c.Status(400).JSON(fiber.Map{"error": "q must be at least 2 characters"})
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "10"))
+	if err != nil || limit < 1 || limit > 50 {
+		return c.Status(400).JSON(fiber.Map{"error": "limit must be between 1 and 50"})
+	}
+
+	suggestions := []positionSuggestion{}
+	if err := applicantQuery(applicantFilters{}).
+		Select("position, similarity(position, ?) AS score", q).
+		Where("position % ?", q).
+		Group("position").
+		Order("score DESC, position ASC").
+		Limit(limit).
+		Scan(&suggestions).Error; err != nil {
+		log.Printf("Database error suggesting positions: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to suggest positions"})
+	}
+
+	return c.JSON(fiber.Map{
+		"query": q,
+		"data":  suggestions,
+	})
+}
@@ -0,0 +1,388 @@
+package controllers
+
+import (
+	"database/sql"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Pagination policy: page defaults to 1 and limit to 10; a page below 1 or a
+// limit outside 1..maxPageLimit is rejected rather than silently adjusted.
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+// parsePagination reads and validates the page and limit query parameters
+func parsePagination(c *fiber.Ctx) (int, int, error) {
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil || page < 1 {
+		return 0, 0, fmt.Errorf("page must be a positive integer")
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultPageLimit)))
+	if err != nil || limit < 1 || limit > maxPageLimit {
+		return 0, 0, fmt.Errorf("limit must be an integer between 1 and %d", maxPageLimit)
+	}
+
+	return page, limit, nil
+}
+
+// parseCursor reads a cursor for cursor pagination: the id of the last
+// applicant already seen, or 0 (or empty) to start from the beginning
+func parseCursor(raw string) (uint, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	cursor, err := strconv.ParseUint(raw, 10, 63)
+	if err != nil {
+		return 0, fmt.Errorf("cursor must be a non-negative integer from next_cursor")
+	}
+	return uint(cursor), nil
+}
+
+// applicantFilters holds the list filters read from the query string. Every
+// endpoint that lists or counts applicants builds its query from these through
+// applicantQuery, so a filter only has to be added in one place.
+type applicantFilters struct {
+	// IncludeDeleted also returns soft-deleted applicants
+	IncludeDeleted bool
+	// Statuses keeps applicants in any of these statuses, sorted and deduplicated
+	Statuses []string
+	// Position keeps applicants for this exact position, ignoring case
+	Position string
+	// IncludeAnonymized also returns applicants whose personal data was redacted
+	IncludeAnonymized bool
+	// MinSalary and MaxSalary bound the expected salary, inclusive. Only
+	// salaries in SalaryCurrency are compared.
+	MinSalary      *int64
+	MaxSalary      *int64
+	SalaryCurrency string
+	// Search keeps applicants whose name, email, position or notes contain
+	// it, ignoring case, or whose notes match it as full-text words
+	Search string
+	// Tag keeps applicants carrying this tag, already normalized
+	Tag string
+}
+
+// maxSearchLength bounds the search term, which ends up in list cache keys
+const maxSearchLength = 100
+
+// parseApplicantFilters reads the list filters from the request
+func parseApplicantFilters(c *fiber.Ctx) (applicantFilters, error) {
+	filters := applicantFilters{
+		IncludeDeleted: c.QueryBool("include_deleted"),
+	}
+
+	// status accepts a single value or a comma-separated list, e.g. pending,reviewed
+	if raw := c.Query("status"); raw != "" {
+		seen := make(map[string]bool)
+		for _, status := range strings.Split(raw, ",") {
+			status = strings.ToLower(utils.SanitizeString(status))
+			if status == "" || seen[status] {
+				continue
+			}
+			if !utils.ValidateStatus(status) {
+				return filters, fmt.Errorf("invalid status value %q", status)
+			}
+			seen[status] = true
+			filters.Statuses = append(filters.Statuses, status)
+		}
+		sort.Strings(filters.Statuses)
+	}
+
+	// position matches the whole title, case-insensitively
+	filters.Position = utils.SanitizeString(c.Query("position"))
+	if utf8.RuneCountInString(filters.Position) > utils.MaxPositionLength {
+		return filters, fmt.Errorf("position must be at most %d characters", utils.MaxPositionLength)
+	}
+
+	// search is a free-text term; empty or blank means no search
+	filters.Search = utils.SanitizeString(c.Query("search"))
+	if utf8.RuneCountInString(filters.Search) > maxSearchLength {
+		return filters, fmt.Errorf("search must be at most %d characters", maxSearchLength)
+	}
+
+	// tag is normalized the way tags are stored, so ?tag=Referral matches
+	if raw := c.Query("tag"); raw != "" {
+		tag, err := normalizeTag(raw)
+		if err != nil {
+			return filters, fmt.Errorf("tag %v", err)
+		}
+		filters.Tag = tag
+	}
+
+	// min_salary and max_salary compare salaries in salary_currency, which
+	// defaults to DEFAULT_CURRENCY
+	var err error
+	if filters.MinSalary, err = parseSalaryBound(c, "min_salary"); err != nil {
+		return filters, err
+	}
+	if filters.MaxSalary, err = parseSalaryBound(c, "max_salary"); err != nil {
+		return filters, err
+	}
+	if filters.MinSalary != nil && filters.MaxSalary != nil && *filters.MinSalary > *filters.MaxSalary {
+		return filters, fmt.Errorf("min_salary must not be greater than max_salary")
+	}
+	if filters.MinSalary != nil || filters.MaxSalary != nil {
+		filters.SalaryCurrency = strings.ToUpper(c.Query("salary_currency", defaultCurrency))
+		if !utils.ValidateCurrency(filters.SalaryCurrency) {
+			return filters, fmt.Errorf("salary_currency must be a three-letter ISO 4217 code")
+		}
+	}
+
+	return filters, nil
+}
+
+// parseSalaryBound reads an optional non-negative salary query parameter
+func parseSalaryBound(c *fiber.Ctx, param string) (*int64, error) {
+	raw := c.Query(param)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || value < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative integer", param)
+	}
+	return &value, nil
+}
+
+// cacheKeySuffix encodes the active filters for use in list cache keys. It is
+// empty when no filter is set so unfiltered keys keep their original form.
+func (f applicantFilters) cacheKeySuffix() string {
+	suffix := ""
+	if f.IncludeDeleted {
+		suffix += "_deleted"
+	}
+	if len(f.Statuses) > 0 {
+		suffix += "_status_" + strings.Join(f.Statuses, ",")
+	}
+	if f.Position != "" {
+		// Quoted so a title containing "_" can't be mistaken for another filter
+		suffix += "_position_" + strconv.Quote(strings.ToLower(f.Position))
+	}
+	if f.MinSalary != nil {
+		suffix += fmt.Sprintf("_minsalary_%d", *f.MinSalary)
+	}
+	if f.MaxSalary != nil {
+		suffix += fmt.Sprintf("_maxsalary_%d", *f.MaxSalary)
+	}
+	if f.SalaryCurrency != "" {
+		suffix += "_" + f.SalaryCurrency
+	}
+	if f.Search != "" {
+		suffix += "_search_" + strconv.Quote(strings.ToLower(f.Search))
+	}
+	if f.Tag != "" {
+		suffix += "_tag_" + strconv.Quote(f.Tag)
+	}
+	return suffix
+}
+
+// likeEscaper escapes the LIKE wildcards so a value is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// applicantListOrder is the oldest-first order of the board and review queue.
+// Every list query ends its ORDER BY with id: timestamps can be equal for rows
+// written in the same transaction, and without a unique last key Postgres may
+// order such rows differently from one page query to the next, repeating or
+// skipping rows.
+const applicantListOrder = "created_at ASC, id ASC"
+
+// sortFields are the columns the applicant list can be sorted by. Only these
+// names ever reach the ORDER BY clause.
+var sortFields = []string{"name", "email", "created_at", "updated_at", "status"}
+
+// applicantSort is the list order requested with sort and order
+type applicantSort struct {
+	Field string
+	Desc  bool
+}
+
+// defaultApplicantSort is newest first
+var defaultApplicantSort = applicantSort{Field: "created_at", Desc: true}
+
+// parseApplicantSort reads sort (a sortFields column) and order (asc or desc),
+// defaulting to created_at desc
+func parseApplicantSort(c *fiber.Ctx) (applicantSort, error) {
+	order := defaultApplicantSort
+	if field := c.Query("sort"); field != "" {
+		if !isSortField(field) {
+			return order, fmt.Errorf("sort must be one of %s", strings.Join(sortFields, ", "))
+		}
+		order.Field = field
+	}
+	switch strings.ToLower(c.Query("order", "desc")) {
+	case "asc":
+		order.Desc = false
+	case "desc":
+		order.Desc = true
+	default:
+		return order, fmt.Errorf("order must be asc or desc")
+	}
+	return order, nil
+}
+
+func isSortField(field string) bool {
+	for _, allowed := range sortFields {
+		if field == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// direction returns "asc" or "desc"
+func (s applicantSort) direction() string {
+	if s.Desc {
+		return "desc"
+	}
+	return "asc"
+}
+
+// clause returns the ORDER BY clause, with id in the same direction as the tiebreaker
+func (s applicantSort) clause() string {
+	direction := strings.ToUpper(s.direction())
+	return fmt.Sprintf("%s %s, id %s", s.Field, direction, direction)
+}
+
+// cacheKeySuffix encodes the order for list cache keys, e.g. "_sort_name_asc"
+func (s applicantSort) cacheKeySuffix() string {
+	return "_sort_" + s.Field + "_" + s.direction()
+}
+
+// applicantQuery returns a query over applicants with the filters applied,
+// ready for Find or Count. It starts a new session so callers can run several
+// finishers on it without them sharing statement state.
+func applicantQuery(f applicantFilters) *gorm.DB {
+	query := database.DB.Model(&models.Applicant{})
+	if f.IncludeDeleted {
+		query = query.Unscoped()
+	}
+	if !f.IncludeAnonymized {
+		query = query.Where("anonymized_at IS NULL")
+	}
+	if len(f.Statuses) > 0 {
+		query = query.Where("status IN ?", f.Statuses)
+	}
+	if f.Position != "" {
+		// ILIKE without wildcards is a case-insensitive equality that the
+		// trigram index on position can serve
+		query = query.Where("position ILIKE ?", likeEscaper.Replace(f.Position))
+	}
+	if f.MinSalary != nil {
+		query = query.Where("expected_salary >= ?", *f.MinSalary)
+	}
+	if f.MaxSalary != nil {
+		query = query.Where("expected_salary <= ?", *f.MaxSalary)
+	}
+	if f.SalaryCurrency != "" {
+		query = query.Where("salary_currency = ?", f.SalaryCurrency)
+	}
+	if f.Search != "" {
+		// The substring matches can use the trigram indexes. Notes also match
+		// by words, so "interviews" finds "interviewed"; the expression must
+		// stay identical to idx_applicants_notes_fts for the index to apply.
+		pattern := "%" + likeEscaper.Replace(f.Search) + "%"
+		query = query.Where(
+			"(name ILIKE @pattern OR email ILIKE @pattern OR position ILIKE @pattern OR notes ILIKE @pattern"+
+				" OR to_tsvector('english', coalesce(notes, '')) @@ plainto_tsquery('english', @term))",
+			sql.Named("pattern", pattern), sql.Named("term", f.Search),
+		)
+	}
+	if f.Tag != "" {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM applicant_tags JOIN tags ON tags.id = applicant_tags.tag_id"+
+				" WHERE applicant_tags.applicant_id = applicants.id AND tags.name = ?)",
+			f.Tag,
+		)
+	}
+	return query.Session(&gorm.Session{})
+}
+
+// applicantPage is one list page as served and cached: the rows and the
+// number of applicants matching the filters, so a cache hit needs no count
+type applicantPage struct {
+	Data  []models.Applicant `json:"data"`
+	Total int64              `json:"total"`
+}
+
+// totalPages is the number of pages of the given size, 0 when nothing matches
+func (p applicantPage) totalPages(limit int) int64 {
+	return (p.Total + int64(limit) - 1) / int64(limit)
+}
+
+// loadApplicantPage counts the applicants matching the filters and reads one
+// page of them in the given order. A page past the last one has no rows.
+func loadApplicantPage(filters applicantFilters, order applicantSort, page, limit int) (applicantPage, error) {
+	result := applicantPage{Data: []models.Applicant{}}
+	if err := applicantQuery(filters).Count(&result.Total).Error; err != nil {
+		return result, err
+	}
+	offset := (page - 1) * limit
+	if int64(offset) >= result.Total {
+		return result, nil
+	}
+	err := applicantQuery(filters).Scopes(withTags).Order(order.clause()).Offset(offset).Limit(limit).Find(&result.Data).Error
+	return result, err
+}
+
+// totalCountHeader carries the number of applicants matching the list filters
+const totalCountHeader = "X-Total-Count"
+
+// setTotalCountHeader counts the applicants matching the filters and reports
+// the total in the X-Total-Count header. A failed count only drops the header.
+func setTotalCountHeader(c *fiber.Ctx, filters applicantFilters) bool {
+	var total int64
+	if err := applicantQuery(filters).Count(&total).Error; err != nil {
+		log.Printf("Database error counting applicants: %v", err)
+		return false
+	}
+	c.Set(totalCountHeader, strconv.FormatInt(total, 10))
+	return true
+}
+
+// HeadApplicants answers HEAD /applicants with the X-Total-Count header for
+// the list filters and no body, without loading any applicants
+func HeadApplicants(c *fiber.Ctx) error {
+	filters, err := parseApplicantFilters(c)
+	if err != nil {
+		c.Status(400)
+		return nil
+	}
+	if !setTotalCountHeader(c, filters) {
+		c.Status(500)
+	}
+	return nil
+}
+
+// timeRange applies the since and until query parameters to created_at
+func timeRange(c *fiber.Ctx, query *gorm.DB) (*gorm.DB, error) {
+	bounds := []struct{ param, condition string }{
+		{"since", "created_at >= ?"},
+		{"until", "created_at < ?"},
+	}
+	for _, bound := range bounds {
+		raw := c.Query(bound.param)
+		if raw == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return query, fmt.Errorf("%s must be an RFC3339 timestamp", bound.param)
+		}
+		query = query.Where(bound.condition, at)
+	}
+	return query, nil
+}
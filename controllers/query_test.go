@@ -0,0 +1,311 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"net/url"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestApplicantSortClauseBreaksTiesByID(t *testing.T) {
+	tests := []struct {
+		order applicantSort
+		want  string
+	}{
+		{defaultApplicantSort, "created_at DESC, id DESC"},
+		{applicantSort{Field: "name"}, "name ASC, id ASC"},
+		{applicantSort{Field: "status", Desc: true}, "status DESC, id DESC"},
+	}
+	for _, tc := range tests {
+		if got := tc.order.clause(); got != tc.want {
+			t.Errorf("%+v clause = %q, want %q", tc.order, got, tc.want)
+		}
+	}
+}
+
+// createTiedApplicants inserts n applicants with the same name, status and
+// timestamps, so only the id tells them apart
+func createTiedApplicants(t *testing.T, n int) []uint {
+	t.Helper()
+	at := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	ids := make([]uint, n)
+	for i := range ids {
+		applicant := createTestApplicant(t, models.Applicant{StatusChangedAt: at})
+		if err := database.DB.Model(&applicant).UpdateColumns(map[string]interface{}{
+			"created_at": at, "updated_at": at,
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+		ids[i] = applicant.ID
+	}
+	return ids
+}
+
+func TestListPagesDoNotOverlapOnTiedTimestamps(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		desc  bool
+	}{
+		{"default", "", true},
+		{"created_at asc", "&sort=created_at&order=asc", false},
+		{"updated_at desc", "&sort=updated_at&order=desc", true},
+		{"name asc", "&sort=name&order=asc", false},
+		{"status desc", "&sort=status&order=desc", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			useTestBackends(t)
+			ids := createTiedApplicants(t, 7)
+			want := append([]uint{}, ids...)
+			if tc.desc {
+				sort.Slice(want, func(i, j int) bool { return want[i] > want[j] })
+			}
+			app := newApplicantTestApp()
+
+			var got []uint
+			for page := 1; page <= 3; page++ {
+				target := fmt.Sprintf("/applicants?limit=3&page=%d%s", page, tc.query)
+				resp, body := doRequest(t, app, newJSONRequest(t, "GET", target, nil))
+				if resp.StatusCode != 200 {
+					t.Fatalf("page %d: status = %d, body %s", page, resp.StatusCode, body)
+				}
+				var result struct {
+					Data []models.Applicant `json:"data"`
+				}
+				decodeJSON(t, body, &result)
+				for _, applicant := range result.Data {
+					got = append(got, applicant.ID)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("ids across pages = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCursorPagesDoNotOverlapOnTiedTimestamps(t *testing.T) {
+	useTestBackends(t)
+	ids := createTiedApplicants(t, 5)
+	app := newApplicantTestApp()
+
+	var got []uint
+	cursor := "0"
+	for cursor != "" {
+		resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/applicants?limit=2&cursor="+cursor, nil))
+		if resp.StatusCode != 200 {
+			t.Fatalf("cursor %s: status = %d, body %s", cursor, resp.StatusCode, body)
+		}
+		var result struct {
+			Data       []models.Applicant `json:"data"`
+			NextCursor *string            `json:"next_cursor"`
+		}
+		decodeJSON(t, body, &result)
+		for _, applicant := range result.Data {
+			got = append(got, applicant.ID)
+		}
+		cursor = ""
+		if result.NextCursor != nil {
+			cursor = *result.NextCursor
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(ids) {
+		t.Errorf("ids across pages = %v, want %v", got, ids)
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		query       string
+		page, limit int
+		err         string
+	}{
+		{"", 1, defaultPageLimit, ""},
+		{"page=3&limit=25", 3, 25, ""},
+		{"limit=1", 1, 1, ""},
+		{"limit=100", 1, 100, ""},
+		{"limit=0", 0, 0, "limit must be an integer between 1 and 100"},
+		{"limit=101", 0, 0, "limit must be an integer between 1 and 100"},
+		{"limit=-5", 0, 0, "limit must be an integer between 1 and 100"},
+		{"limit=ten", 0, 0, "limit must be an integer between 1 and 100"},
+		{"limit=2.5", 0, 0, "limit must be an integer between 1 and 100"},
+		{"page=0", 0, 0, "page must be a positive integer"},
+		{"page=-1", 0, 0, "page must be a positive integer"},
+		{"page=two", 0, 0, "page must be a positive integer"},
+		{"page=99999999999999999999", 0, 0, "page must be a positive integer"},
+	}
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		page, limit, err := parsePagination(c)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(fiber.Map{"page": page, "limit": limit})
+	})
+	for _, tc := range tests {
+		_, body := doRequest(t, app, newJSONRequest(t, "GET", "/?"+tc.query, nil))
+		var got struct {
+			Page  int    `json:"page"`
+			Limit int    `json:"limit"`
+			Error string `json:"error"`
+		}
+		decodeJSON(t, body, &got)
+		if got.Page != tc.page || got.Limit != tc.limit || got.Error != tc.err {
+			t.Errorf("parsePagination(%q) = %d, %d, %q, want %d, %d, %q",
+				tc.query, got.Page, got.Limit, got.Error, tc.page, tc.limit, tc.err)
+		}
+	}
+}
+
+func TestListRejectsInvalidPagination(t *testing.T) {
+	useTestBackends(t)
+	app := newApplicantTestApp()
+	for _, query := range []string{"limit=0", "limit=101", "limit=-5", "limit=abc", "page=0", "page=abc"} {
+		resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/applicants?"+query, nil))
+		if resp.StatusCode != 400 {
+			t.Errorf("GET /applicants?%s: status = %d, want 400, body %s", query, resp.StatusCode, body)
+		}
+	}
+}
+
+func TestStatusFilter(t *testing.T) {
+	useTestBackends(t)
+	byStatus := map[utils.Status][]uint{}
+	for _, status := range []utils.Status{utils.StatusPending, utils.StatusPending, utils.StatusReviewed, utils.StatusHired, utils.StatusRejected} {
+		applicant := createTestApplicant(t, models.Applicant{Status: status})
+		byStatus[status] = append(byStatus[status], applicant.ID)
+	}
+	app := newApplicantTestApp()
+
+	tests := []struct {
+		status string
+		want   []utils.Status
+	}{
+		{"pending", []utils.Status{utils.StatusPending}},
+		{"hired", []utils.Status{utils.StatusHired}},
+		{"interviewed", nil},
+		{"pending,reviewed", []utils.Status{utils.StatusPending, utils.StatusReviewed}},
+		{" Reviewed , PENDING,pending,", []utils.Status{utils.StatusPending, utils.StatusReviewed}},
+		{"hired,rejected,interviewed", []utils.Status{utils.StatusHired, utils.StatusRejected}},
+	}
+	for _, tc := range tests {
+		var want []uint
+		for _, status := range tc.want {
+			want = append(want, byStatus[status]...)
+		}
+		sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+		query := "status=" + url.QueryEscape(tc.status)
+
+		resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/applicants?sort=created_at&order=asc&"+query, nil))
+		if resp.StatusCode != 200 {
+			t.Fatalf("%s: status = %d, body %s", tc.status, resp.StatusCode, body)
+		}
+		var page struct {
+			Data  []models.Applicant `json:"data"`
+			Total int                `json:"total"`
+		}
+		decodeJSON(t, body, &page)
+		var got []uint
+		for _, applicant := range page.Data {
+			got = append(got, applicant.ID)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) || page.Total != len(want) {
+			t.Errorf("status=%s: ids %v, total %d, want %v", tc.status, got, page.Total, want)
+		}
+
+		resp, _ = doRequest(t, app, newJSONRequest(t, "HEAD", "/applicants?"+query, nil))
+		if got := resp.Header.Get(totalCountHeader); got != fmt.Sprint(len(want)) {
+			t.Errorf("HEAD status=%s: %s = %q, want %d", tc.status, totalCountHeader, got, len(want))
+		}
+	}
+
+	for _, status := range []string{"pending,archived", "PENDINGX"} {
+		resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/applicants?status="+url.QueryEscape(status), nil))
+		if resp.StatusCode != 400 {
+			t.Errorf("status=%s: status = %d, want 400, body %s", status, resp.StatusCode, body)
+		}
+	}
+}
+
+func TestStatusFilterCacheKey(t *testing.T) {
+	keyFor := func(raw string) string {
+		app := fiber.New()
+		var key string
+		app.Get("/", func(c *fiber.Ctx) error {
+			filters, err := parseApplicantFilters(c)
+			if err != nil {
+				return err
+			}
+			key = filters.cacheKeySuffix()
+			return nil
+		})
+		doRequest(t, app, newJSONRequest(t, "GET", "/?status="+url.QueryEscape(raw), nil))
+		return key
+	}
+	if a, b := keyFor("reviewed,pending"), keyFor("Pending, reviewed,pending"); a != b || a != "_status_pending,reviewed" {
+		t.Errorf("equivalent filters got keys %q and %q, want both _status_pending,reviewed", a, b)
+	}
+	if a, b := keyFor("pending"), keyFor("pending,reviewed"); a == b {
+		t.Errorf("different filters share the key %q", a)
+	}
+}
+
+func TestTotalCountHeader(t *testing.T) {
+	useTestBackends(t)
+	for i := 0; i < 5; i++ {
+		createTestApplicant(t, models.Applicant{})
+	}
+	deleted := createTestApplicant(t, models.Applicant{Status: utils.StatusReviewed})
+	if err := database.DB.Delete(&deleted).Error; err != nil {
+		t.Fatal(err)
+	}
+	createTestApplicant(t, models.Applicant{Status: utils.StatusHired})
+	app := newApplicantTestApp()
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "6"},
+		{"limit=2&page=2", "6"},
+		{"status=hired", "1"},
+		{"status=reviewed", "0"},
+		{"status=reviewed&include_deleted=true", "1"},
+		{"include_deleted=true", "7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			// The second GET is served from the list cache
+			for _, method := range []string{"HEAD", "GET", "GET"} {
+				resp, body := doRequest(t, app, newJSONRequest(t, method, "/applicants?"+tt.query, nil))
+				if resp.StatusCode != 200 {
+					t.Fatalf("%s status = %d, want 200", method, resp.StatusCode)
+				}
+				if got := resp.Header.Get(totalCountHeader); got != tt.want {
+					t.Errorf("%s %s = %q, want %s", method, totalCountHeader, got, tt.want)
+				}
+				if method == "HEAD" && len(body) != 0 {
+					t.Errorf("HEAD body = %q, want none", body)
+				}
+			}
+		})
+	}
+}
+
+func TestHeadApplicantsInvalidFilter(t *testing.T) {
+	useTestBackends(t)
+	resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "HEAD", "/applicants?status=unknown", nil))
+	if resp.StatusCode != 400 {
+		t.Errorf("status = %d, want 400", resp.StatusCode)
+	}
+	if resp.Header.Get(totalCountHeader) != "" || len(body) != 0 {
+		t.Errorf("invalid filter returned %s %q and body %q", totalCountHeader, resp.Header.Get(totalCountHeader), body)
+	}
+}
@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"job-tracker/quota"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMyQuota returns the authenticated user's quota usage and reset times
+func GetMyQuota(tracker *quota.Tracker) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("user_role").(string)
+
+		usage, err := tracker.Usage(c.UserContext(), currentUser(c), role)
+		if err != nil {
+			log.Printf("Redis error reading quota usage: %v", err)
+			return c.Status(503).JSON(fiber.Map{"error": "Quota usage unavailable"})
+		}
+
+		return c.JSON(fiber.Map{
+			"user_id": currentUser(c),
+			"role":    role,
+			"quota":   usage,
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"job-tracker/quota"
+	"testing"
+)
+
+func TestGetMyQuota(t *testing.T) {
+	server := useTestRedis(t)
+	tracker := quota.NewTracker(rdb, quota.Limits{"default": 10}, quota.Limits{"default": 0})
+	for i := 0; i < 3; i++ {
+		if _, err := tracker.Consume(ctx, "recruiter@example.com", "recruiter"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	app := newTestApp()
+	app.Get("/me/quota", GetMyQuota(tracker))
+
+	resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/me/quota", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+	var result struct {
+		UserID string      `json:"user_id"`
+		Role   string      `json:"role"`
+		Quota  quota.Usage `json:"quota"`
+	}
+	decodeJSON(t, body, &result)
+	if result.UserID != "recruiter@example.com" || result.Role != "recruiter" {
+		t.Errorf("user = %s (%s)", result.UserID, result.Role)
+	}
+	// Reading the quota doesn't count as a request
+	if daily := result.Quota.Daily; daily.Limit != 10 || daily.Used != 3 || daily.Remaining != 7 || daily.ResetAt.IsZero() {
+		t.Errorf("daily = %+v, want 3 of 10 used", daily)
+	}
+	if monthly := result.Quota.Monthly; monthly.Limit != 0 || monthly.Remaining != -1 {
+		t.Errorf("monthly = %+v, want unlimited", monthly)
+	}
+
+	server.Close()
+	if resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/me/quota", nil)); resp.StatusCode != 503 {
+		t.Errorf("Redis down: status = %d, want 503, body %s", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"job-tracker/config"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxWarmPages caps how many list pages a reconcile may pre-load
+const maxWarmPages = config.MaxCacheWarmPages
+
+// warmLimits are the page sizes pre-loaded when warming the list cache
+var warmLimits = []int{defaultPageLimit, 20}
+
+// reconcileReport describes what a cache reconcile did
+type reconcileReport struct {
+	Deleted      int64    `json:"deleted"`
+	Warmed       []string `json:"warmed"`
+	CreatedTotal int64    `json:"created_total"`
+}
+
+// reconcileCache drops every cached applicant list page and applicant, resets the created
+// counter from the database and re-loads the first warmPages unfiltered pages
+// so the next readers don't all miss at once
+func reconcileCache(warmPages int) (reconcileReport, error) {
+	report := reconcileReport{Warmed: []string{}}
+
+	deleted, err := deleteKeysByPattern(applicantCachePatterns...)
+	report.Deleted = deleted
+	if err != nil {
+		return report, fmt.Errorf("flush applicant cache: %w", err)
+	}
+
+	if report.CreatedTotal, err = reconcileCreatedCounter(); err != nil {
+		return report, fmt.Errorf("reconcile created counter: %w", err)
+	}
+
+	for page := 1; page <= warmPages; page++ {
+		for _, limit := range warmLimits {
+			key := fmt.Sprintf("applicants_page_%d_limit_%d", page, limit) + defaultApplicantSort.cacheKeySuffix()
+			loaded, err := loadApplicantPage(applicantFilters{}, defaultApplicantSort, page, limit)
+			if err != nil {
+				return report, fmt.Errorf("load %s: %w", key, err)
+			}
+			data, err := json.Marshal(loaded)
+			if err != nil {
+				return report, fmt.Errorf("encode %s: %w", key, err)
+			}
+			if err := rdb.Set(ctx, key, data, listCacheTTL).Err(); err != nil {
+				return report, fmt.Errorf("write %s: %w", key, err)
+			}
+			report.Warmed = append(report.Warmed, key)
+		}
+	}
+
+	log.Printf("Cache reconciled: %d keys flushed, %d warmed, created counter at %d",
+		report.Deleted, len(report.Warmed), report.CreatedTotal)
+	return report, nil
+}
+
+// ReconcileCacheOnStart reconciles the cache at startup when
+// RECONCILE_CACHE_ON_START is enabled. Failures are logged, not fatal.
+func ReconcileCacheOnStart() {
+	if !reconcileOnStart {
+		return
+	}
+	if _, err := reconcileCache(cacheWarmPages); err != nil {
+		log.Printf("Warning: startup cache reconcile failed: %v", err)
+	}
+}
+
+// ReconcileApplicantCache is a safety net for when the cache may have drifted
+// from the database: it flushes the applicant cache, resets the created
+// counter and, with warm_pages (default 1, 0 to skip), pre-loads list pages
+func ReconcileApplicantCache(c *fiber.Ctx) error {
+	warmPages, err := strconv.Atoi(c.Query("warm_pages", "1"))
+	if err != nil || warmPages < 0 || warmPages > maxWarmPages {
+		return c.Status(400).JSON(fiber.Map{
+			"error": fmt.Sprintf("warm_pages must be an integer between 0 and %d", maxWarmPages),
+		})
+	}
+
+	report, err := reconcileCache(warmPages)
+	if err != nil {
+		log.Printf("Cache reconcile failed: %v", err)
+		return c.Status(503).JSON(fiber.Map{"error": "Cache reconcile failed", "report": report})
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"job-tracker/database"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// reindexMu lets only one rebuild run at a time
+var reindexMu sync.Mutex
+
+// applicantIndex is one of the idx_applicants_* indexes with its on-disk size
+type applicantIndex struct {
+	Name       string  `json:"name"`
+	SizeBytes  int64   `json:"size_bytes"`
+	DurationMs float64 `json:"duration_ms,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// listApplicantIndexes returns the idx_applicants_* indexes and their sizes
+func listApplicantIndexes() ([]applicantIndex, error) {
+	var indexes []applicantIndex
+	err := database.DB.Raw(`SELECT c.relname AS name, pg_relation_size(c.oid) AS size_bytes
+		FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indexrelid
+		WHERE i.indrelid = 'applicants'::regclass AND c.relname LIKE 'idx\_applicants\_%'
+		ORDER BY c.relname`).Scan(&indexes).Error
+	return indexes, err
+}
+
+// ReindexApplicants rebuilds the idx_applicants_* indexes to recover from
+// bloat. Each index is rebuilt with REINDEX CONCURRENTLY so reads and writes
+// carry on meanwhile. With dry_run=true it only reports the index sizes; a
+// real rebuild must be confirmed with confirm=true.
+func ReindexApplicants(c *fiber.Ctx) error {
+	dryRun := c.QueryBool("dry_run")
+	if !dryRun && !c.QueryBool("confirm") {
+		return c.Status(400).JSON(fiber.Map{
+			"error": "Reindexing rebuilds every applicant index; pass confirm=true to proceed or dry_run=true to preview",
+		})
+	}
+
+	if !reindexMu.TryLock() {
+		return c.Status(409).JSON(fiber.Map{"error": "A reindex is already running"})
+	}
+	defer reindexMu.Unlock()
+
+	indexes, err := listApplicantIndexes()
+	if err != nil {
+		log.Printf("Database error listing applicant indexes: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to list indexes"})
+	}
+	if dryRun {
+		return c.JSON(fiber.Map{"dry_run": true, "indexes": indexes})
+	}
+
+	start := time.Now()
+	failed := 0
+	for i := range indexes {
+		index := &indexes[i]
+		quoted := `"` + strings.ReplaceAll(index.Name, `"`, `""`) + `"`
+
+		indexStart := time.Now()
+		if err := database.DB.Exec("REINDEX INDEX CONCURRENTLY " + quoted).Error; err != nil {
+			log.Printf("Failed to reindex %s: %v", index.Name, err)
+			index.Error = err.Error()
+			failed++
+		}
+		index.DurationMs = float64(time.Since(indexStart).Microseconds()) / 1000
+	}
+
+	// Report the sizes after the rebuild
+	if rebuilt, err := listApplicantIndexes(); err == nil {
+		sizes := make(map[string]int64, len(rebuilt))
+		for _, index := range rebuilt {
+			sizes[index.Name] = index.SizeBytes
+		}
+		for i := range indexes {
+			indexes[i].SizeBytes = sizes[indexes[i].Name]
+		}
+	}
+
+	log.Printf("Reindexed %d applicant indexes (%d failed) in %s", len(indexes), failed, time.Since(start))
+	return c.JSON(fiber.Map{
+		"dry_run":     false,
+		"indexes":     indexes,
+		"failed":      failed,
+		"duration_ms": float64(time.Since(start).Microseconds()) / 1000,
+	})
+}
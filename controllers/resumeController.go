@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"job-tracker/database"
+	"job-tracker/models"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Sources of a resume in the zip manifest
+const (
+	resumeSourceUpload = "upload"
+	resumeSourceText   = "text"
+)
+
+// resumeManifestEntry describes one requested id in the zip manifest. Source
+// is upload for an uploaded PDF and text for the pasted resume.
+type resumeManifestEntry struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name,omitempty"`
+	File   string `json:"file,omitempty"`
+	Source string `json:"source,omitempty"`
+	Result string `json:"result"`
+}
+
+// resumeFileName builds the archive entry name for an applicant's resume with
+// the given extension, keeping only letters and digits from the name so it is
+// safe on any system
+func resumeFileName(applicant *models.Applicant, ext string) string {
+	var b strings.Builder
+	for _, r := range applicant.Name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "_"):
+			b.WriteByte('_')
+		}
+	}
+	name := strings.TrimSuffix(b.String(), "_")
+	if name == "" {
+		return fmt.Sprintf("%d.%s", applicant.ID, ext)
+	}
+	return fmt.Sprintf("%d_%s.%s", applicant.ID, name, ext)
+}
+
+// addResumeToZip writes an applicant's resume into the archive, preferring
+// the uploaded PDF and falling back to the pasted text when there is no
+// upload or its file can't be read. It fills in the entry's file, source and
+// result, and fails only when the archive itself can't be written.
+func addResumeToZip(archive *zip.Writer, applicant *models.Applicant, entry *resumeManifestEntry) error {
+	if applicant.ResumeFile != "" {
+		pdf, err := os.Open(applicant.ResumeFile)
+		if err == nil {
+			defer pdf.Close()
+			entry.File, entry.Source = resumeFileName(applicant, "pdf"), resumeSourceUpload
+			// PDFs are already compressed
+			file, err := archive.CreateHeader(&zip.FileHeader{
+				Name:     entry.File,
+				Method:   zip.Store,
+				Modified: time.Now().UTC(),
+			})
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(file, pdf); err != nil {
+				return err
+			}
+			entry.Result = "included"
+			return nil
+		}
+		log.Printf("Resume file %s of applicant %d is unavailable: %v", applicant.ResumeFile, applicant.ID, err)
+		entry.Result = "file_missing"
+	}
+	if applicant.Resume == "" {
+		return nil
+	}
+
+	entry.File, entry.Source = resumeFileName(applicant, "txt"), resumeSourceText
+	file, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     entry.File,
+		Method:   zip.Deflate,
+		Modified: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write([]byte(applicant.Resume)); err != nil {
+		return err
+	}
+	entry.Result = "included"
+	return nil
+}
+
+// ZipResumes streams a zip archive holding the resume of every requested
+// applicant, plus a manifest.json reporting what happened to each id and
+// where its resume came from. Uploaded PDFs are archived as <id>_<name>.pdf
+// and pasted resumes as <id>_<name>.txt. Applicants without a resume are
+// listed in the manifest but not archived.
+func ZipResumes(c *fiber.Ctx) error {
+	ids, err := parseBatchIDs(c)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	var lookup []int64
+	for _, id := range ids {
+		if id > 0 {
+			lookup = append(lookup, id)
+		}
+	}
+
+	rows, err := database.DB.Model(&models.Applicant{}).
+		Select("id", "name", "resume", "resume_file").
+		Where("id IN ?", lookup).
+		Order("id ASC").
+		Rows()
+	if err != nil {
+		log.Printf("Database error loading resumes: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load resumes"})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="resumes.zip"`)
+
+	// Resumes are written one at a time as they are read so memory stays bounded
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer rows.Close()
+
+		archive := zip.NewWriter(w)
+		found := make(map[int64]resumeManifestEntry, len(lookup))
+		archived := 0
+		for rows.Next() {
+			var applicant models.Applicant
+			if err := database.DB.ScanRows(rows, &applicant); err != nil {
+				log.Printf("Database error reading resume row: %v", err)
+				break
+			}
+
+			entry := resumeManifestEntry{ID: int64(applicant.ID), Name: applicant.Name, Result: "no_resume"}
+			if err := addResumeToZip(archive, &applicant, &entry); err != nil {
+				log.Printf("Failed to add resume of applicant %d to zip: %v", applicant.ID, err)
+				break
+			}
+			if entry.Result == "included" {
+				archived++
+			}
+			found[entry.ID] = entry
+		}
+		if err := rows.Err(); err != nil {
+			log.Printf("Database error during resume zip: %v", err)
+		}
+
+		// The manifest follows the request order, reporting each id once
+		manifest := make([]resumeManifestEntry, 0, len(ids))
+		reported := make(map[int64]bool, len(ids))
+		for _, id := range ids {
+			if reported[id] {
+				continue
+			}
+			reported[id] = true
+
+			switch entry, ok := found[id]; {
+			case id <= 0:
+				manifest = append(manifest, resumeManifestEntry{ID: id, Result: "invalid"})
+			case ok:
+				manifest = append(manifest, entry)
+			default:
+				manifest = append(manifest, resumeManifestEntry{ID: id, Result: "not_found"})
+			}
+		}
+		if file, err := archive.Create("manifest.json"); err == nil {
+			encoder := json.NewEncoder(file)
+			encoder.SetIndent("", "  ")
+			encoder.Encode(manifest)
+		} else {
+			log.Printf("Failed to add manifest to resume zip: %v", err)
+		}
+
+		if err := archive.Close(); err != nil {
+			log.Printf("Failed to finish resume zip: %v", err)
+		}
+		log.Printf("Zipped %d resumes for %d requested applicants", archived, len(manifest))
+	})
+
+	return nil
+}
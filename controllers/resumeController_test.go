@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"job-tracker/config"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"testing"
+	"time"
+)
+
+// testPDF is the smallest content http.DetectContentType reports as a PDF
+var testPDF = []byte("%PDF-1.4\n1 0 obj <<>> endobj\ntrailer <<>>\n%%EOF\n")
+
+// useTestUploads stores uploads in a temporary directory for the duration of the test
+func useTestUploads(t *testing.T) {
+	t.Helper()
+	previous := uploadConfig
+	SetUploadConfig(config.UploadConfig{MaxBytes: 1 << 20, Dir: t.TempDir()})
+	t.Cleanup(func() { SetUploadConfig(previous) })
+}
+
+// newUploadRequest builds a multipart upload of content as the "file" field
+func newUploadRequest(t *testing.T, target, contentType string, content []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="resume.pdf"`)
+	header.Set("Content-Type", contentType)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	form.Close()
+
+	req := httptest.NewRequest("POST", target, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+// readZip returns the files of a zip archive by name
+func readZip(t *testing.T, body []byte) map[string][]byte {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	files := make(map[string][]byte, len(archive.File))
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name], _ = io.ReadAll(r)
+		r.Close()
+	}
+	return files
+}
+
+func TestZipResumesIncludesUploadedPDFs(t *testing.T) {
+	useTestBackends(t)
+	useTestUploads(t)
+	app := newApplicantTestApp()
+	uploaded := createTestApplicant(t, models.Applicant{Name: "Ada Lovelace", Resume: "pasted resume"})
+	pasted := createTestApplicant(t, models.Applicant{Name: "Grace Hopper", Resume: "compilers"})
+	missing := createTestApplicant(t, models.Applicant{Name: "Alan Turing", ResumeFile: "/nonexistent/resume.pdf"})
+	fallback := createTestApplicant(t, models.Applicant{Name: "Edsger Dijkstra", Resume: "paths", ResumeFile: "/nonexistent/resume.pdf"})
+	none := createTestApplicant(t, models.Applicant{Name: "Barbara Liskov"})
+
+	resp, body := doRequest(t, app, newUploadRequest(t, fmt.Sprintf("/applicants/%d/resume", uploaded.ID), "application/pdf", testPDF))
+	if resp.StatusCode != 200 {
+		t.Fatalf("upload: status = %d, body %s", resp.StatusCode, body)
+	}
+
+	ids := []uint{uploaded.ID, pasted.ID, missing.ID, fallback.ID, none.ID, 9999}
+	resp, body = doRequest(t, app, newJSONRequest(t, "POST", "/applicants/resumes/zip", map[string][]uint{"ids": ids}))
+	if resp.StatusCode != 200 || resp.Header.Get("Content-Type") != "application/zip" {
+		t.Fatalf("zip: status = %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	files := readZip(t, body)
+
+	pdfName := fmt.Sprintf("%d_Ada_Lovelace.pdf", uploaded.ID)
+	if !bytes.Equal(files[pdfName], testPDF) {
+		t.Errorf("%s = %q, want the uploaded PDF", pdfName, files[pdfName])
+	}
+	if name := fmt.Sprintf("%d_Ada_Lovelace.txt", uploaded.ID); files[name] != nil {
+		t.Errorf("%s archived alongside the uploaded PDF", name)
+	}
+	if got := string(files[fmt.Sprintf("%d_Grace_Hopper.txt", pasted.ID)]); got != "compilers" {
+		t.Errorf("pasted resume = %q, want compilers", got)
+	}
+	if got := string(files[fmt.Sprintf("%d_Edsger_Dijkstra.txt", fallback.ID)]); got != "paths" {
+		t.Errorf("fallback resume = %q, want the pasted text", got)
+	}
+	if len(files) != 4 {
+		t.Errorf("zip holds %d files, want 3 resumes and the manifest", len(files))
+	}
+
+	var manifest []resumeManifestEntry
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	want := []resumeManifestEntry{
+		{ID: int64(uploaded.ID), Name: "Ada Lovelace", File: pdfName, Source: resumeSourceUpload, Result: "included"},
+		{ID: int64(pasted.ID), Name: "Grace Hopper", File: fmt.Sprintf("%d_Grace_Hopper.txt", pasted.ID), Source: resumeSourceText, Result: "included"},
+		{ID: int64(missing.ID), Name: "Alan Turing", Result: "file_missing"},
+		{ID: int64(fallback.ID), Name: "Edsger Dijkstra", File: fmt.Sprintf("%d_Edsger_Dijkstra.txt", fallback.ID), Source: resumeSourceText, Result: "included"},
+		{ID: int64(none.ID), Name: "Barbara Liskov", Result: "no_resume"},
+		{ID: 9999, Result: "not_found"},
+	}
+	if fmt.Sprint(manifest) != fmt.Sprint(want) {
+		t.Errorf("manifest = %+v\nwant %+v", manifest, want)
+	}
+}
+
+func TestUploadResumeRejectsNonPDF(t *testing.T) {
+	useTestBackends(t)
+	useTestUploads(t)
+	applicant := createTestApplicant(t, models.Applicant{})
+	target := fmt.Sprintf("/applicants/%d/resume", applicant.ID)
+
+	tests := []struct {
+		name        string
+		contentType string
+		content     []byte
+	}{
+		{"text declared as PDF", "application/pdf", []byte("plain text")},
+		{"PDF declared as text", "text/plain", testPDF},
+	}
+	for _, tc := range tests {
+		resp, body := doRequest(t, newApplicantTestApp(), newUploadRequest(t, target, tc.contentType, tc.content))
+		if resp.StatusCode != 400 {
+			t.Errorf("%s: status = %d, body %s, want 400", tc.name, resp.StatusCode, body)
+		}
+	}
+	entries, _ := os.ReadDir(uploadConfig.Dir)
+	if len(entries) != 0 {
+		t.Errorf("upload directory holds %d files, want none stored", len(entries))
+	}
+}
+
+func TestUploadResumeAtTheSizeLimit(t *testing.T) {
+	useTestBackends(t)
+	cfg := config.UploadConfig{MaxConcurrent: 1, MaxBytes: 32 << 10, RetryAfter: time.Second, Dir: t.TempDir()}
+	previous := uploadConfig
+	SetUploadConfig(cfg)
+	t.Cleanup(func() { SetUploadConfig(previous) })
+	applicant := createTestApplicant(t, models.Applicant{})
+
+	// Mounted as routes.Setup does, behind the upload limiter
+	app := newTestApp()
+	app.Post("/applicants/:id/resume", middleware.UploadLimiter(cfg), UploadResume)
+	target := fmt.Sprintf("/applicants/%d/resume", applicant.ID)
+
+	for _, tc := range []struct {
+		size int64
+		want int
+	}{
+		{cfg.MaxBytes, 200},
+		{cfg.MaxBytes + 1, 413},
+	} {
+		pdf := append(append([]byte{}, testPDF...), bytes.Repeat([]byte{' '}, int(tc.size)-len(testPDF))...)
+		resp, body := doRequest(t, app, newUploadRequest(t, target, "application/pdf", pdf))
+		if resp.StatusCode != tc.want {
+			t.Errorf("%d byte PDF: status = %d, body %s, want %d", tc.size, resp.StatusCode, body, tc.want)
+		}
+	}
+}
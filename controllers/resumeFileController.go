@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"job-tracker/config"
+	"job-tracker/database"
+	"job-tracker/models"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// resumeContentType is the only type accepted for resume uploads
+const resumeContentType = "application/pdf"
+
+// uploadConfig holds the upload size limit and the directory files are stored in
+var uploadConfig = config.UploadConfig{MaxBytes: 5 << 20, Dir: "uploads"}
+
+// SetUploadConfig sets the limits and storage directory for uploads
+func SetUploadConfig(cfg config.UploadConfig) {
+	uploadConfig = cfg
+}
+
+// resumeFilePath is where an applicant's resume PDF is stored. Each applicant
+// has one file, so a new upload replaces the previous one.
+func resumeFilePath(id uint) string {
+	return filepath.Join(uploadConfig.Dir, fmt.Sprintf("resume_%d.pdf", id))
+}
+
+// isPDF checks both the declared content type and the file's leading bytes,
+// so a renamed file of another type is rejected
+func isPDF(header string, file io.Reader) bool {
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil || mediaType != resumeContentType {
+		return false
+	}
+	sniff := make([]byte, 512)
+	n, _ := io.ReadFull(file, sniff)
+	return http.DetectContentType(sniff[:n]) == resumeContentType
+}
+
+// UploadResume stores a PDF sent as the multipart field "file" and records
+// its path in resume_file. Non-PDF uploads return 400 and files over
+// UPLOAD_MAX_BYTES return 413. The change is audited like an update.
+func UploadResume(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var applicant models.Applicant
+	if err := database.DB.First(&applicant, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+		}
+		log.Printf("Database error fetching applicant %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to upload resume"})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "file is required as a multipart form field"})
+	}
+	if file.Size > uploadConfig.MaxBytes {
+		return c.Status(413).JSON(fiber.Map{
+			"error": fmt.Sprintf("Upload exceeds the %d byte limit", uploadConfig.MaxBytes),
+		})
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		log.Printf("Failed to open resume upload for applicant %d: %v", id, err)
+		return c.Status(400).JSON(fiber.Map{"error": "Failed to read upload"})
+	}
+	pdf := isPDF(file.Header.Get(fiber.HeaderContentType), content)
+	content.Close()
+	if !pdf {
+		return c.Status(400).JSON(fiber.Map{"error": "resume must be a PDF"})
+	}
+
+	// Write to a temporary name and rename, so a download never sees a
+	// partly written file
+	path := resumeFilePath(applicant.ID)
+	if err := os.MkdirAll(uploadConfig.Dir, 0o750); err != nil {
+		log.Printf("Failed to create upload directory %s: %v", uploadConfig.Dir, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to store resume"})
+	}
+	tmp := path + ".tmp"
+	if err := c.SaveFile(file, tmp); err != nil {
+		log.Printf("Failed to save resume for applicant %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to store resume"})
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		log.Printf("Failed to store resume for applicant %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to store resume"})
+	}
+	log.Printf("Stored %d byte resume for applicant %d", file.Size, id)
+
+	return saveApplicantUpdate(c, applicant, func(tx *gorm.DB, applicant *models.Applicant) error {
+		return tx.Model(applicant).Update("resume_file", path).Error
+	})
+}
+
+// DownloadResume streams an applicant's uploaded resume PDF
+func DownloadResume(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var applicant models.Applicant
+	if err := database.DB.First(&applicant, id).Error; err != nil {
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+	}
+	if applicant.ResumeFile == "" {
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant has no uploaded resume"})
+	}
+	if _, err := os.Stat(applicant.ResumeFile); err != nil {
+		log.Printf("Resume file %s of applicant %d is unavailable: %v", applicant.ResumeFile, id, err)
+		return c.Status(404).JSON(fiber.Map{"error": "Resume file not found"})
+	}
+
+	c.Set(fiber.HeaderContentType, resumeContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="resume_%d.pdf"`, applicant.ID))
+	return c.SendFile(applicant.ResumeFile)
+}
+
+// removeResumeFile deletes a stored resume, logging rather than failing
+func removeResumeFile(path string) {
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to remove resume file %s: %v", path, err)
+	}
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"job-tracker/models"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// reviewLockTTL is how long GET /applicants/next reserves an applicant for the
+// reviewer it was handed to
+var reviewLockTTL = 15 * time.Minute
+
+// nextCandidates is how many queued applicants are read at a time while
+// looking for one no other reviewer holds
+const nextCandidates = 20
+
+// reviewLockKey is the Redis key reserving an applicant. It is kept outside
+// the applicant cache namespaces so cache flushes leave locks alone.
+func reviewLockKey(id uint) string {
+	return fmt.Sprintf("review_lock:%d", id)
+}
+
+// releaseReviewLock frees an applicant reserved through /applicants/next once
+// someone has acted on it
+func releaseReviewLock(id uint) {
+	if err := rdb.Del(ctx, reviewLockKey(id)).Err(); err != nil {
+		log.Printf("Redis error releasing review lock on applicant %d: %v", id, err)
+	}
+}
+
+// claimReviewLock reserves an applicant for a reviewer. A lock the reviewer
+// already holds is renewed, so asking again returns the same applicant.
+func claimReviewLock(id uint, reviewer string) (bool, error) {
+	key := reviewLockKey(id)
+	claimed, err := rdb.SetNX(ctx, key, reviewer, reviewLockTTL).Result()
+	if err != nil || claimed {
+		return claimed, err
+	}
+	holder, err := rdb.Get(ctx, key).Result()
+	if err != nil || holder != reviewer {
+		// The lock may have expired in between; the next call will pick it up
+		return false, nil
+	}
+	return true, rdb.Expire(ctx, key, reviewLockTTL).Err()
+}
+
+// NextApplicant returns the oldest pending applicant for review. Unless
+// lock=false, the applicant is locked to the requesting reviewer for
+// REVIEW_LOCK_TTL and skipped by other reviewers until then, or until someone
+// changes its status, updates or deletes it.
+func NextApplicant(c *fiber.Ctx) error {
+	queue := applicantQuery(applicantFilters{Statuses: []string{"pending"}}).
+		Order(applicantListOrder)
+
+	if !c.QueryBool("lock", true) {
+		var applicant models.Applicant
+		if err := queue.Scopes(withTags).First(&applicant).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return c.Status(404).JSON(fiber.Map{"error": "No pending applicants"})
+			}
+			log.Printf("Database error fetching next applicant: %v", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch next applicant"})
+		}
+		return c.JSON(fiber.Map{"data": applicant})
+	}
+
+	reviewer := currentUser(c)
+	for offset := 0; ; offset += nextCandidates {
+		var candidates []models.Applicant
+		if err := queue.Session(&gorm.Session{}).Scopes(withTags).Offset(offset).Limit(nextCandidates).Find(&candidates).Error; err != nil {
+			log.Printf("Database error fetching next applicant: %v", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch next applicant"})
+		}
+		if len(candidates) == 0 {
+			return c.Status(404).JSON(fiber.Map{"error": "No pending applicants left to review"})
+		}
+
+		for _, applicant := range candidates {
+			claimed, err := claimReviewLock(applicant.ID, reviewer)
+			if err != nil {
+				log.Printf("Redis error locking applicant %d for review: %v", applicant.ID, err)
+				return c.Status(503).JSON(fiber.Map{"error": "Review locks unavailable"})
+			}
+			if claimed {
+				log.Printf("Applicant %d locked for review by %s", applicant.ID, reviewer)
+				return c.JSON(fiber.Map{
+					"data":         applicant,
+					"locked_by":    reviewer,
+					"locked_until": time.Now().UTC().Add(reviewLockTTL),
+				})
+			}
+		}
+	}
+}
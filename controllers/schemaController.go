@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"job-tracker/middleware"
+	"job-tracker/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fieldConstraint describes the rules for one applicant field, as advertised
+// to clients building forms
+type fieldConstraint struct {
+	Type      middleware.FieldType `json:"type"`
+	Required  bool                 `json:"required"`
+	Updatable bool                 `json:"updatable"`
+	MinLength int                  `json:"min_length,omitempty"`
+	MaxLength int                  `json:"max_length,omitempty"`
+	Pattern   string               `json:"pattern,omitempty"`
+	Format    string               `json:"format,omitempty"`
+	Enum      []string             `json:"enum,omitempty"`
+	Minimum   *int                 `json:"minimum,omitempty"`
+}
+
+// statusInfo describes one status and where an applicant can go from it
+type statusInfo struct {
+	Value       string   `json:"value"`
+	Terminal    bool     `json:"terminal"`
+	Transitions []string `json:"transitions"`
+}
+
+// applicantFilterParams are the query parameters the list endpoints filter on
+var applicantFilterParams = []string{
+	"status", "position", "min_salary", "max_salary", "salary_currency",
+	"include_deleted", "include_anonymized", "updated_since",
+}
+
+// GetApplicantSchema describes the applicant fields, statuses and list filters
+// so clients can build forms from the server's rules. Everything is derived
+// from the running configuration, so limits and required fields changed
+// through the environment are reflected.
+func GetApplicantSchema(c *fiber.Ctx) error {
+	zero := 0
+	// Rules the request schemas don't express, checked by the handlers
+	rules := map[string]fieldConstraint{
+		"name":            {MaxLength: 100},
+		"email":           {MaxLength: 150, Format: "email"},
+		"position":        {MinLength: utils.MinPositionLength, MaxLength: utils.MaxPositionLength},
+		"status":          {Enum: utils.AllowedStatuses()},
+		"phone":           {Format: "phone"},
+		"phone_extension": {Pattern: `^\d{1,6}$`},
+		"resume":          {MaxLength: maxResumeLength},
+		"notes":           {MaxLength: maxNotesLength},
+		"linkedin_url":    {MaxLength: maxURLLength, Format: "uri"},
+		"portfolio_url":   {MaxLength: maxURLLength, Format: "uri"},
+		"expected_salary": {Minimum: &zero},
+		"salary_currency": {Pattern: `^[A-Z]{3}$`},
+	}
+
+	fields := make(map[string]fieldConstraint, len(ApplicantCreateSchema))
+	for name, field := range ApplicantCreateSchema {
+		constraint := rules[name]
+		constraint.Type = field.Type
+		constraint.Required = field.Required
+		constraint.Updatable = updatableFields[name]
+		fields[name] = constraint
+	}
+
+	statuses := make([]statusInfo, 0, len(utils.AllowedStatuses()))
+	for _, status := range utils.AllowedStatuses() {
+		statuses = append(statuses, statusInfo{
+			Value:       status,
+			Terminal:    utils.IsTerminalStatus(status),
+			Transitions: utils.StatusTransitions(status),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"fields":           fields,
+		"required":         requiredFields,
+		"statuses":         statuses,
+		"default_status":   defaultStatus,
+		"default_currency": defaultCurrency,
+		"filters":          applicantFilterParams,
+		"sort_fields":      sortFields,
+		"default_sort":     fiber.Map{"sort": defaultApplicantSort.Field, "order": defaultApplicantSort.direction()},
+		"max_page_limit":   maxPageLimit,
+	})
+}
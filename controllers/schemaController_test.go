@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"job-tracker/utils"
+	"testing"
+)
+
+func TestApplicantSchemaStatusesMatchValidation(t *testing.T) {
+	app := newTestApp()
+	app.Get("/applicants/schema", GetApplicantSchema)
+	resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/applicants/schema", nil))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+
+	var schema struct {
+		Fields map[string]struct {
+			Enum []string `json:"enum"`
+		} `json:"fields"`
+		Statuses      []statusInfo `json:"statuses"`
+		DefaultStatus string       `json:"default_status"`
+	}
+	decodeJSON(t, body, &schema)
+
+	advertised := map[string]bool{}
+	for _, status := range schema.Statuses {
+		advertised[status.Value] = true
+	}
+	// Every advertised status validates, and nothing else does
+	candidates := append(utils.AllowedStatuses(), "", "Pending", "withdrawn", "offer", "archived")
+	for _, status := range candidates {
+		if utils.ValidateStatus(status) != advertised[status] {
+			t.Errorf("status %q: advertised %v, ValidateStatus %v", status, advertised[status], utils.ValidateStatus(status))
+		}
+	}
+	if len(schema.Statuses) != len(utils.AllowedStatuses()) {
+		t.Errorf("advertised %d statuses, want %d", len(schema.Statuses), len(utils.AllowedStatuses()))
+	}
+	if enum := schema.Fields["status"].Enum; len(enum) != len(schema.Statuses) {
+		t.Errorf("status field enum = %v, want the advertised statuses", enum)
+	}
+	if !advertised[schema.DefaultStatus] {
+		t.Errorf("default status %q is not advertised", schema.DefaultStatus)
+	}
+
+	for _, status := range schema.Statuses {
+		if status.Terminal != (len(status.Transitions) == 0) {
+			t.Errorf("%s: terminal = %v with transitions %v", status.Value, status.Terminal, status.Transitions)
+		}
+		allowed := map[string]bool{}
+		for _, to := range status.Transitions {
+			allowed[to] = true
+		}
+		for _, to := range utils.AllowedStatuses() {
+			if utils.ValidateTransition(status.Value, to) != allowed[to] {
+				t.Errorf("%s -> %s: advertised %v, ValidateTransition %v", status.Value, to, allowed[to], !allowed[to])
+			}
+		}
+	}
+}
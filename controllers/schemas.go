@@ -0,0 +1,89 @@
+package controllers
+
+import "job-tracker/middleware"
+
+// Request body schemas checked by middleware.ValidateBody before the handlers run
+
+// ApplicantCreateSchema is the body accepted by CreateApplicant
+var ApplicantCreateSchema = middleware.Schema{
+	"name":     {Type: middleware.TypeString, Required: true},
+	"email":    {Type: middleware.TypeString, Required: true},
+	"position": {Type: middleware.TypeString, Required: true},
+	"status":   {Type: middleware.TypeString},
+	"phone":    {Type: middleware.TypeString},
+	"resume":   {Type: middleware.TypeString},
+	"notes":    {Type: middleware.TypeString},
+
+	"linkedin_url":  {Type: middleware.TypeString},
+	"portfolio_url": {Type: middleware.TypeString},
+
+	"phone_extension": {Type: middleware.TypeString},
+	"expected_salary": {Type: middleware.TypeNumber},
+	"salary_currency": {Type: middleware.TypeString},
+}
+
+// ApplicantUpdateSchema is the body accepted by UpdateApplicant
+var ApplicantUpdateSchema = middleware.Schema{
+	"name":     {Type: middleware.TypeString},
+	"email":    {Type: middleware.TypeString},
+	"position": {Type: middleware.TypeString},
+	"status":   {Type: middleware.TypeString},
+	"phone":    {Type: middleware.TypeString},
+	"resume":   {Type: middleware.TypeString},
+	"notes":    {Type: middleware.TypeString},
+
+	"linkedin_url":  {Type: middleware.TypeString},
+	"portfolio_url": {Type: middleware.TypeString},
+
+	"phone_extension": {Type: middleware.TypeString},
+	"expected_salary": {Type: middleware.TypeNumber},
+	"salary_currency": {Type: middleware.TypeString},
+}
+
+// TransitionSchema is the body accepted by TransitionApplicant
+var TransitionSchema = middleware.Schema{
+	"to":     {Type: middleware.TypeString, Required: true},
+	"reason": {Type: middleware.TypeString},
+}
+
+// BatchIDsSchema is the body accepted by the batch endpoints
+var BatchIDsSchema = middleware.Schema{
+	"ids": {Type: middleware.TypeArray, Required: true},
+}
+
+// ReopenSchema is the body accepted by ReopenApplicant
+var ReopenSchema = middleware.Schema{
+	"reason": {Type: middleware.TypeString, Required: true},
+}
+
+// AppendNoteSchema is the body accepted by AppendApplicantNote
+var AppendNoteSchema = middleware.Schema{
+	"text": {Type: middleware.TypeString, Required: true},
+}
+
+// CommentSchema is the body accepted by CreateComment
+var CommentSchema = middleware.Schema{
+	"body": {Type: middleware.TypeString, Required: true},
+}
+
+// TagsSchema is the body accepted by AddApplicantTags
+var TagsSchema = middleware.Schema{
+	"tags": {Type: middleware.TypeArray, Required: true},
+}
+
+// WebhookSchema is the body accepted by CreateWebhook
+var WebhookSchema = middleware.Schema{
+	"url":    {Type: middleware.TypeString, Required: true},
+	"events": {Type: middleware.TypeArray},
+}
+
+// CloneSchema is the body accepted by CloneApplicant
+var CloneSchema = middleware.Schema{
+	"email": {Type: middleware.TypeString, Required: true},
+}
+
+// LoginSchema is the body accepted by Login
+var LoginSchema = middleware.Schema{
+	"email":    {Type: middleware.TypeString, Required: true},
+	"password": {Type: middleware.TypeString, Required: true},
+}
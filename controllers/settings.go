@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/config"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"sort"
+	"time"
+)
+
+// statusSLA holds the maximum time an applicant may stay in each status before
+// being reported as overdue. Statuses without an entry never become overdue.
+var statusSLA = map[string]time.Duration{
+	"pending":     72 * time.Hour,
+	"reviewed":    120 * time.Hour,
+	"interviewed": 168 * time.Hour,
+}
+
+// Maximum number of characters accepted for the free-text fields
+var (
+	maxNotesLength   = 5000
+	maxResumeLength  = 20000
+	maxCommentLength = 2000
+)
+
+// exportChunkSize is the number of rows each export query reads
+var exportChunkSize = 1000
+
+// maxBatchSize caps the number of ids accepted by the batch endpoints
+var maxBatchSize = 100
+
+// requirePhoneCountryCode rejects phone numbers without a "+" country code prefix
+var requirePhoneCountryCode = false
+
+// defaultPhoneCountryCode is the calling code given to phone numbers entered
+// without one. When empty such numbers are stored as bare digits.
+var defaultPhoneCountryCode = ""
+
+// applicantMaxAge is how long clients and proxies may cache a single applicant
+// response; zero disables caching
+var applicantMaxAge = 30 * time.Second
+
+// updatableFields lists the fields UpdateApplicant may change. It defaults to
+// every field in ApplicantUpdateSchema and is narrowed with UPDATABLE_FIELDS.
+var updatableFields = schemaFields(ApplicantUpdateSchema)
+
+// Startup cache reconcile: whether to run it and how many list pages to warm
+var (
+	reconcileOnStart = false
+	cacheWarmPages   = 1
+)
+
+// requiredFields are the fields CreateApplicant requires. name, email and
+// position are always required; REQUIRED_FIELDS can add others such as phone.
+var requiredFields = []string{"email", "name", "position"}
+
+// emailNormalizeProviders lists the mail domains whose addresses ignore dots
+// and +tags in the local part, such as gmail.com. Empty disables normalization.
+var emailNormalizeProviders = map[string]bool{}
+
+// defaultCurrency applies to salaries given without a currency and to salary
+// filters without one
+var defaultCurrency = "USD"
+
+// defaultStatus is given to new applicants created without a status
+var defaultStatus = utils.StatusPending
+
+// emailReusePolicy decides what creating an applicant with the email of a
+// soft-deleted one does: reject, restore or release
+var emailReusePolicy = config.EmailReuseReject
+
+// Reopen override: the status a terminal applicant is moved back to, and the
+// roles allowed to do it
+var (
+	reopenStatus = utils.StatusReviewed
+	reopenRoles  = []string{"admin"}
+)
+
+// ReopenRoles returns the roles allowed to reopen applicants in terminal statuses
+func ReopenRoles() []string {
+	return reopenRoles
+}
+
+// SetApplicantConfig applies the applicant rules. It fails if a field in
+// UPDATABLE_FIELDS or REQUIRED_FIELDS is not one the API accepts.
+func SetApplicantConfig(cfg config.ApplicantsConfig) error {
+	updatable := schemaFields(ApplicantUpdateSchema)
+	if len(cfg.UpdatableFields) > 0 {
+		updatable = make(map[string]bool, len(cfg.UpdatableFields))
+	}
+	for _, field := range cfg.UpdatableFields {
+		if _, ok := ApplicantUpdateSchema[field]; !ok {
+			return fmt.Errorf("UPDATABLE_FIELDS: %q is not an updatable applicant field", field)
+		}
+		updatable[field] = true
+	}
+
+	required, err := parseRequiredFields(cfg.RequiredFields)
+	if err != nil {
+		return fmt.Errorf("REQUIRED_FIELDS: %w", err)
+	}
+
+	providers := make(map[string]bool, len(cfg.EmailNormalizeProviders))
+	for _, domain := range cfg.EmailNormalizeProviders {
+		providers[domain] = true
+	}
+
+	statusSLA = cfg.StatusSLA
+	maxNotesLength = cfg.MaxNotesLength
+	maxResumeLength = cfg.MaxResumeLength
+	maxCommentLength = cfg.MaxCommentLength
+	maxBatchSize = cfg.MaxBatchSize
+	exportChunkSize = cfg.ExportChunkSize
+	applicantMaxAge = cfg.CacheMaxAge
+	reviewLockTTL = cfg.ReviewLockTTL
+	updatableFields = updatable
+	setRequiredFields(required)
+	emailNormalizeProviders = providers
+	defaultCurrency = cfg.DefaultCurrency
+	defaultStatus = cfg.DefaultStatus
+	defaultPhoneCountryCode = cfg.DefaultPhoneCountryCode
+	requirePhoneCountryCode = cfg.RequirePhoneCountryCode
+	emailReusePolicy = cfg.EmailReusePolicy
+	reopenStatus = cfg.ReopenStatus
+	reopenRoles = cfg.ReopenRoles
+	return nil
+}
+
+// schemaFields returns the set of fields in a schema
+func schemaFields(schema middleware.Schema) map[string]bool {
+	fields := make(map[string]bool, len(schema))
+	for field := range schema {
+		fields[field] = true
+	}
+	return fields
+}
+
+// setRequiredFields makes fields the required set of ApplicantCreateSchema
+func setRequiredFields(fields []string) {
+	requiredFields = fields
+	for name, field := range ApplicantCreateSchema {
+		field.Required = false
+		ApplicantCreateSchema[name] = field
+	}
+	for _, name := range fields {
+		field := ApplicantCreateSchema[name]
+		field.Required = true
+		ApplicantCreateSchema[name] = field
+	}
+}
+
+// parseRequiredFields checks every entry of REQUIRED_FIELDS is an applicant
+// field accepted on create. The result is sorted and always includes name,
+// email and position, which the table requires.
+func parseRequiredFields(extra []string) ([]string, error) {
+	required := map[string]bool{"name": true, "email": true, "position": true}
+	for _, field := range extra {
+		if _, ok := ApplicantCreateSchema[field]; !ok || !models.IsApplicantField(field) {
+			return nil, fmt.Errorf("%q is not an applicant field accepted on create", field)
+		}
+		required[field] = true
+	}
+
+	fields := make([]string, 0, len(required))
+	for field := range required {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
@@ -0,0 +1,183 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/config"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"strings"
+	"testing"
+)
+
+func TestSetApplicantConfigRejectsUnknownFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *config.ApplicantsConfig)
+		want   string
+	}{
+		{"updatable id", func(cfg *config.ApplicantsConfig) { cfg.UpdatableFields = []string{"name", "id"} }, "UPDATABLE_FIELDS"},
+		{"updatable unknown", func(cfg *config.ApplicantsConfig) { cfg.UpdatableFields = []string{"nickname"} }, "UPDATABLE_FIELDS"},
+		{"required unknown", func(cfg *config.ApplicantsConfig) { cfg.RequiredFields = []string{"nickname"} }, "REQUIRED_FIELDS"},
+		{"required created_at", func(cfg *config.ApplicantsConfig) { cfg.RequiredFields = []string{"created_at"} }, "REQUIRED_FIELDS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultApplicantConfig()
+			tt.modify(&cfg)
+			err := SetApplicantConfig(cfg)
+			if err == nil {
+				t.Fatal("SetApplicantConfig succeeded, want an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not name %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetApplicantConfigAppliesFieldSets(t *testing.T) {
+	cfg := defaultApplicantConfig()
+	cfg.UpdatableFields = []string{"name", "position"}
+	cfg.RequiredFields = []string{"phone"}
+	useApplicantConfig(t, cfg)
+
+	if !updatableFields["name"] || !updatableFields["position"] || updatableFields["email"] {
+		t.Errorf("updatableFields = %v, want only name and position", updatableFields)
+	}
+	if got := strings.Join(requiredFields, ","); got != "email,name,phone,position" {
+		t.Errorf("requiredFields = %s, want email,name,phone,position", got)
+	}
+	if !ApplicantCreateSchema["phone"].Required || ApplicantCreateSchema["notes"].Required {
+		t.Error("ApplicantCreateSchema required flags don't match REQUIRED_FIELDS")
+	}
+}
+
+func TestSetApplicantConfigDefaultsAllowEveryField(t *testing.T) {
+	useApplicantConfig(t, defaultApplicantConfig())
+
+	for field := range ApplicantUpdateSchema {
+		if !updatableFields[field] {
+			t.Errorf("field %q is not updatable by default", field)
+		}
+	}
+	if ApplicantCreateSchema["phone"].Required {
+		t.Error("phone is required by default")
+	}
+}
+
+func TestPhoneCountryCodeRequirement(t *testing.T) {
+	tests := []struct {
+		name           string
+		require        bool
+		defaultCountry string
+		phone          string
+		wantPhone      string
+		wantRejected   bool
+	}{
+		{"lenient keeps local numbers", false, "", "(555) 123-4567", "5551234567", false},
+		{"lenient keeps international numbers", false, "", "+44 20 7946 0958", "+442079460958", false},
+		{"strict rejects local numbers", true, "", "(555) 123-4567", "", true},
+		{"strict rejects unassigned codes", true, "", "+999 1234 5678", "", true},
+		{"strict accepts international numbers", true, "", "+44 20 7946 0958", "+442079460958", false},
+		{"strict with a default country", true, "1", "(555) 123-4567", "+15551234567", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			useTestBackends(t)
+			cfg := defaultApplicantConfig()
+			cfg.RequirePhoneCountryCode = tc.require
+			cfg.DefaultPhoneCountryCode = tc.defaultCountry
+			useApplicantConfig(t, cfg)
+
+			resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "POST", "/applicants", map[string]string{
+				"name": "Ada Lovelace", "email": "ada@example.com", "position": "Engineer", "phone": tc.phone,
+			}))
+			if tc.wantRejected {
+				var result struct {
+					Errors []utils.FieldError `json:"errors"`
+				}
+				decodeJSON(t, body, &result)
+				if resp.StatusCode != 422 || len(result.Errors) != 1 || result.Errors[0].Field != "phone" {
+					t.Errorf("status = %d, body %s, want 422 for the phone", resp.StatusCode, body)
+				}
+				return
+			}
+			if resp.StatusCode != 201 {
+				t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+			}
+			var created models.Applicant
+			decodeJSON(t, body, &created)
+			if string(created.Phone) != tc.wantPhone {
+				t.Errorf("phone = %q, want %q", created.Phone, tc.wantPhone)
+			}
+		})
+	}
+}
+
+func TestRequiredPhone(t *testing.T) {
+	useTestBackends(t)
+	cfg := defaultApplicantConfig()
+	cfg.RequiredFields = []string{"phone"}
+	useApplicantConfig(t, cfg)
+	app := newApplicantTestApp()
+
+	errorFields := func(body []byte) string {
+		var result struct {
+			Errors []utils.FieldError `json:"errors"`
+		}
+		decodeJSON(t, body, &result)
+		fields := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			fields[i] = e.Field
+		}
+		return strings.Join(fields, ",")
+	}
+
+	// Every missing field is reported in one response
+	resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants", map[string]string{}))
+	if resp.StatusCode != 422 || errorFields(body) != "email,name,phone,position" {
+		t.Errorf("empty create: status %d, body %s, want 422 for email, name, phone and position", resp.StatusCode, body)
+	}
+
+	for _, phone := range []interface{}{nil, ""} {
+		item := map[string]interface{}{"name": "Ada", "email": "ada@example.com", "position": "Engineer"}
+		if phone != nil {
+			item["phone"] = phone
+		}
+		resp, body := doRequest(t, app, newJSONRequest(t, "POST", "/applicants", item))
+		if resp.StatusCode != 422 || errorFields(body) != "phone" {
+			t.Errorf("create with phone %v: status %d, body %s, want 422 for phone", phone, resp.StatusCode, body)
+		}
+		resp, body = doRequest(t, app, newJSONRequest(t, "POST", "/applicants/bulk", []interface{}{item}))
+		if resp.StatusCode != 422 {
+			t.Errorf("bulk create with phone %v: status %d, body %s, want 422", phone, resp.StatusCode, body)
+		}
+	}
+
+	item := map[string]string{"name": "Ada", "email": "ada@example.com", "position": "Engineer", "phone": "+44 20 7946 0958"}
+	resp, body = doRequest(t, app, newJSONRequest(t, "POST", "/applicants", item))
+	if resp.StatusCode != 201 {
+		t.Fatalf("create with phone: status %d, body %s, want 201", resp.StatusCode, body)
+	}
+	var created models.Applicant
+	decodeJSON(t, body, &created)
+
+	// A required field can be changed but not cleared
+	target := fmt.Sprintf("/applicants/%d", created.ID)
+	resp, body = doRequest(t, app, newJSONRequest(t, "PATCH", target, map[string]string{"phone": ""}))
+	if resp.StatusCode != 422 || !strings.Contains(string(body), `"field":"phone"`) {
+		t.Errorf("clearing phone: status %d, body %s, want 422 for phone", resp.StatusCode, body)
+	}
+	resp, body = doRequest(t, app, newJSONRequest(t, "PATCH", target, map[string]string{"phone": "+44 20 7946 0959"}))
+	if resp.StatusCode != 200 {
+		t.Errorf("changing phone: status %d, body %s, want 200", resp.StatusCode, body)
+	}
+}
+
+func TestPhoneOptionalByDefault(t *testing.T) {
+	useTestBackends(t)
+	item := map[string]string{"name": "Ada", "email": "ada@example.com", "position": "Engineer"}
+	if resp, body := doRequest(t, newApplicantTestApp(), newJSONRequest(t, "POST", "/applicants", item)); resp.StatusCode != 201 {
+		t.Errorf("status %d, body %s, want 201 without a phone", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetApplicantStats returns the number of live applicants per status. It reads
+// the trigger-maintained summary table rather than grouping the applicants
+// table, so its cost doesn't grow with the number of applicants.
+func GetApplicantStats(c *fiber.Ctx) error {
+	var rows []models.ApplicantStatusCount
+	if err := database.DB.Find(&rows).Error; err != nil {
+		log.Printf("Database error loading status summary: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load applicant stats"})
+	}
+
+	byStatus := make(map[string]int64)
+	for _, status := range utils.AllowedStatuses() {
+		byStatus[status] = 0
+	}
+	var total int64
+	for _, row := range rows {
+		byStatus[row.Status] = row.Count
+		total += row.Count
+	}
+
+	return c.JSON(fiber.Map{
+		"total":     total,
+		"by_status": byStatus,
+	})
+}
@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"job-tracker/workflow"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TransitionRequest is the body accepted by TransitionApplicant
+type TransitionRequest struct {
+	To     string `json:"to"`
+	Reason string `json:"reason"`
+}
+
+// ReopenRequest is the body accepted by ReopenApplicant
+type ReopenRequest struct {
+	Reason string `json:"reason"`
+}
+
+// errIllegalTransition is returned when the state machine forbids a status change
+var errIllegalTransition = errors.New("illegal status transition")
+
+// changeStatus moves an applicant to a new status inside a transaction, locking
+// the row so concurrent changes apply one after another. allowed decides whether
+// the move from the current status is permitted. It returns the previous status.
+func changeStatus(id uint, to utils.Status, action, actor, reason string, allowed func(from utils.Status) bool) (utils.Status, error) {
+	var from utils.Status
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var applicant models.Applicant
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&applicant, id).Error; err != nil {
+			return err
+		}
+
+		from = applicant.Status
+		if !allowed(from) {
+			return errIllegalTransition
+		}
+
+		if err := tx.Model(&applicant).Updates(map[string]interface{}{
+			"status":            to,
+			"status_changed_at": time.Now().UTC(),
+		}).Error; err != nil {
+			return err
+		}
+
+		changes := map[string]models.FieldChange{"status": {Old: from, New: to}}
+		if err := recordAudit(tx, applicant.ID, action, actor, changes, reason); err != nil {
+			return err
+		}
+		if from != to {
+			if err := recordStatusChange(tx, applicant.ID, from, to, actor); err != nil {
+				return err
+			}
+		}
+
+		_, err := applyWorkflow(tx, &applicant, from, to, actor)
+		return err
+	})
+	if err == nil {
+		invalidateApplicantCache(id)
+		releaseReviewLock(id)
+	}
+	return from, err
+}
+
+// applyWorkflow runs the workflow rules configured for a status change on the
+// locked applicant. Their changes are audited separately so automated changes
+// are distinguishable from the user's.
+func applyWorkflow(tx *gorm.DB, applicant *models.Applicant, from, to utils.Status, actor string) (map[string]models.FieldChange, error) {
+	ruleChanges, err := workflow.Apply(tx, applicant, from, to)
+	if err != nil || len(ruleChanges) == 0 {
+		return ruleChanges, err
+	}
+	return ruleChanges, recordAudit(tx, applicant.ID, "workflow", actor, ruleChanges, fmt.Sprintf("rules for %s to %s", from, to))
+}
+
+// respondStatusChange reports the outcome of changeStatus, returning the
+// updated applicant and notifying them on success
+func respondStatusChange(c *fiber.Ctx, id uint, from, to utils.Status, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+	case errors.Is(err, errIllegalTransition):
+		return c.Status(422).JSON(fiber.Map{
+			"error": fmt.Sprintf("Cannot transition from %s to %s", from, to),
+		})
+	case err != nil:
+		log.Printf("[%s] Database error changing status of applicant %d: %v", middleware.RequestID(c), id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to change applicant status"})
+	}
+
+	var applicant models.Applicant
+	if err := database.DB.Scopes(withTags).First(&applicant, id).Error; err != nil {
+		log.Printf("[%s] Database error reloading applicant %d: %v", middleware.RequestID(c), id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to load updated applicant"})
+	}
+
+	invalidateApplicantListCache()
+	log.Printf("Applicant %d moved from %s to %s", id, from, to)
+	notifyStatusChange(applicant, from, currentUser(c))
+
+	return c.JSON(applicant)
+}
+
+// TransitionApplicant moves an applicant to a new status, enforcing the allowed
+// status flow and recording the change in the audit log
+func TransitionApplicant(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var req TransitionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	to := cleanStatus(utils.Status(req.To))
+	req.Reason = utils.SanitizeString(req.Reason)
+
+	if !to.Valid() {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid status value"})
+	}
+
+	from, err := changeStatus(id, to, "transition", currentUser(c), req.Reason, func(from utils.Status) bool {
+		return from.CanTransitionTo(to)
+	})
+	return respondStatusChange(c, id, from, to, err)
+}
+
+// ReopenApplicant moves a hired or rejected applicant back into the pipeline.
+// Terminal statuses normally can't be left, so this is an explicit override
+// that requires a reason and is recorded in the audit log.
+func ReopenApplicant(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var req ReopenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	req.Reason = utils.SanitizeString(req.Reason)
+	if req.Reason == "" {
+		return c.Status(422).JSON(fiber.Map{"error": "reason is required", "field": "reason"})
+	}
+
+	from, err := changeStatus(id, reopenStatus, "reopen", currentUser(c), req.Reason, utils.Status.IsTerminal)
+	return respondStatusChange(c, id, from, reopenStatus, err)
+}
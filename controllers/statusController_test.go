@@ -0,0 +1,290 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"job-tracker/workflow"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useWorkflowRules loads workflow rules for the duration of the test
+func useWorkflowRules(t *testing.T, rules []workflow.Rule) {
+	t.Helper()
+	data, err := json.Marshal(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := workflow.LoadRules(path); err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	t.Cleanup(func() { workflow.LoadRules("") })
+}
+
+// auditActions returns the audit actions recorded for an applicant, oldest first
+func auditActions(t *testing.T, id uint) []string {
+	t.Helper()
+	var actions []string
+	if err := database.DB.Model(&models.AuditLog{}).Where("applicant_id = ?", id).Order("id ASC").Pluck("action", &actions).Error; err != nil {
+		t.Fatal(err)
+	}
+	return actions
+}
+
+func TestStatusChangeRunsWorkflowRules(t *testing.T) {
+	for _, method := range []string{"PUT", "PATCH"} {
+		t.Run(method, func(t *testing.T) {
+			useTestBackends(t)
+			useWorkflowRules(t, []workflow.Rule{
+				{To: utils.StatusRejected, Actions: []workflow.Action{
+					{Type: "set_field", Field: "portfolio_url", Value: ""},
+					{Type: "append_note", Value: "Removed from the active pipeline"},
+				}},
+			})
+			applicant := createTestApplicant(t, models.Applicant{
+				Status: utils.StatusReviewed, PortfolioURL: "https://example.com/ada",
+			})
+			app := newApplicantTestApp()
+
+			resp, body := doRequest(t, app, newJSONRequest(t, method, fmt.Sprintf("/applicants/%d", applicant.ID),
+				map[string]string{"status": "rejected"}))
+			if resp.StatusCode != 200 {
+				t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+			}
+
+			var got models.Applicant
+			decodeJSON(t, body, &got)
+			if got.Status != utils.StatusRejected || got.PortfolioURL != "" || !strings.HasSuffix(got.Notes, "Removed from the active pipeline") {
+				t.Errorf("response = status %q, portfolio %q, notes %q, want the rules applied", got.Status, got.PortfolioURL, got.Notes)
+			}
+			var stored models.Applicant
+			if err := database.DB.First(&stored, applicant.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			if stored.PortfolioURL != "" || stored.Notes != got.Notes {
+				t.Errorf("stored portfolio %q, notes %q, want the rules saved", stored.PortfolioURL, stored.Notes)
+			}
+			if got, want := strings.Join(auditActions(t, applicant.ID), ","), "update,workflow"; got != want {
+				t.Errorf("audit actions = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestUpdateWithoutStatusChangeSkipsWorkflowRules(t *testing.T) {
+	useTestBackends(t)
+	useWorkflowRules(t, []workflow.Rule{
+		{To: utils.StatusReviewed, Actions: []workflow.Action{{Type: "append_note", Value: "should not run"}}},
+	})
+	applicant := createTestApplicant(t, models.Applicant{Status: utils.StatusReviewed})
+	app := newApplicantTestApp()
+
+	resp, body := doRequest(t, app, newJSONRequest(t, "PUT", fmt.Sprintf("/applicants/%d", applicant.ID),
+		map[string]string{"status": "reviewed", "position": "Staff Engineer"}))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+	var got models.Applicant
+	decodeJSON(t, body, &got)
+	if got.Notes != "" {
+		t.Errorf("notes = %q, want no rule to run without a status change", got.Notes)
+	}
+	if got, want := strings.Join(auditActions(t, applicant.ID), ","), "update"; got != want {
+		t.Errorf("audit actions = %s, want %s", got, want)
+	}
+}
+
+func TestTransitionRunsWorkflowRules(t *testing.T) {
+	useTestBackends(t)
+	useWorkflowRules(t, []workflow.Rule{
+		{From: utils.StatusPending, To: utils.StatusRejected, Actions: []workflow.Action{{Type: "append_note", Value: "Rejected at screening"}}},
+	})
+	applicant := createTestApplicant(t, models.Applicant{Status: utils.StatusPending})
+	app := newApplicantTestApp()
+
+	resp, body := doRequest(t, app, newJSONRequest(t, "POST", fmt.Sprintf("/applicants/%d/transition", applicant.ID),
+		map[string]string{"to": "rejected"}))
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+	var got models.Applicant
+	decodeJSON(t, body, &got)
+	if !strings.HasSuffix(got.Notes, "Rejected at screening") {
+		t.Errorf("notes = %q, want the rule's note", got.Notes)
+	}
+	if got, want := strings.Join(auditActions(t, applicant.ID), ","), "transition,workflow"; got != want {
+		t.Errorf("audit actions = %s, want %s", got, want)
+	}
+}
+
+func TestTransitionApplicant(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   utils.Status
+		to     string
+		status int
+	}{
+		{"pending to reviewed", utils.StatusPending, "reviewed", 200},
+		{"pending to rejected", utils.StatusPending, "rejected", 200},
+		{"reviewed to interviewed", utils.StatusReviewed, "interviewed", 200},
+		{"interviewed to hired", utils.StatusInterviewed, "hired", 200},
+		{"pending to hired", utils.StatusPending, "hired", 422},
+		{"reviewed back to pending", utils.StatusReviewed, "pending", 422},
+		{"out of rejected", utils.StatusRejected, "reviewed", 422},
+		{"out of hired", utils.StatusHired, "rejected", 422},
+		{"to the same status", utils.StatusReviewed, "reviewed", 422},
+		{"unknown status", utils.StatusPending, "archived", 400},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, queue := useTestBackends(t)
+			applicant := createTestApplicant(t, models.Applicant{Status: tc.from})
+			req := newJSONRequest(t, "POST", fmt.Sprintf("/applicants/%d/transition", applicant.ID),
+				map[string]string{"to": tc.to, "reason": "Panel feedback"})
+			resp, body := doRequest(t, newApplicantTestApp(), req)
+			if resp.StatusCode != tc.status {
+				t.Fatalf("status = %d, want %d, body %s", resp.StatusCode, tc.status, body)
+			}
+
+			var stored models.Applicant
+			if err := database.DB.First(&stored, applicant.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			var audits []models.AuditLog
+			if err := database.DB.Where("applicant_id = ?", applicant.ID).Find(&audits).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.status != 200 {
+				if stored.Status != tc.from {
+					t.Errorf("stored status = %s, want %s unchanged", stored.Status, tc.from)
+				}
+				if len(audits) != 0 || len(queue.names) != 0 {
+					t.Errorf("rejected transition left %d audit rows and queued %v", len(audits), queue.names)
+				}
+				return
+			}
+
+			var got models.Applicant
+			decodeJSON(t, body, &got)
+			if string(got.Status) != tc.to || string(stored.Status) != tc.to {
+				t.Errorf("response status %s, stored %s, want %s", got.Status, stored.Status, tc.to)
+			}
+			if !stored.StatusChangedAt.After(applicant.StatusChangedAt) {
+				t.Errorf("status_changed_at = %v, want after %v", stored.StatusChangedAt, applicant.StatusChangedAt)
+			}
+			if len(audits) != 1 {
+				t.Fatalf("%d audit rows, want 1", len(audits))
+			}
+			audit := audits[0]
+			var changes map[string]models.FieldChange
+			if err := json.Unmarshal(audit.Changes, &changes); err != nil {
+				t.Fatal(err)
+			}
+			if audit.Action != "transition" || audit.Actor != "recruiter@example.com" || audit.Reason != "Panel feedback" ||
+				changes["status"].Old != string(tc.from) || changes["status"].New != tc.to {
+				t.Errorf("audit = %+v with changes %v", audit, changes)
+			}
+			var history []models.StatusHistory
+			if err := database.DB.Where("applicant_id = ?", applicant.ID).Find(&history).Error; err != nil {
+				t.Fatal(err)
+			}
+			if len(history) != 1 || history[0].FromStatus != tc.from || string(history[0].ToStatus) != tc.to {
+				t.Errorf("status history = %+v", history)
+			}
+			if want := fmt.Sprintf("status-email:%d", applicant.ID); strings.Join(queue.names, ",") != want {
+				t.Errorf("queued jobs = %v, want %s", queue.names, want)
+			}
+		})
+	}
+}
+
+func TestTransitionApplicantNotFound(t *testing.T) {
+	useTestBackends(t)
+	req := newJSONRequest(t, "POST", "/applicants/999/transition", map[string]string{"to": "reviewed"})
+	if resp, body := doRequest(t, newApplicantTestApp(), req); resp.StatusCode != 404 {
+		t.Fatalf("status = %d, want 404, body %s", resp.StatusCode, body)
+	}
+}
+
+func TestReopenApplicant(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   utils.Status
+		role   string
+		body   map[string]string
+		status int
+	}{
+		{"rejected as admin", utils.StatusRejected, "admin", map[string]string{"reason": "Hiring manager asked again"}, 200},
+		{"hired as admin", utils.StatusHired, "admin", map[string]string{"reason": "Offer withdrawn"}, 200},
+		{"not terminal", utils.StatusInterviewed, "admin", map[string]string{"reason": "Retry"}, 422},
+		{"without a reason", utils.StatusRejected, "admin", map[string]string{}, 422},
+		{"blank reason", utils.StatusRejected, "admin", map[string]string{"reason": "   "}, 422},
+		{"as recruiter", utils.StatusRejected, "recruiter", map[string]string{"reason": "Retry"}, 403},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			useTestBackends(t)
+			applicant := createTestApplicant(t, models.Applicant{Status: tc.from})
+			req := newJSONRequest(t, "POST", fmt.Sprintf("/applicants/%d/reopen", applicant.ID), tc.body)
+			req.Header.Set(testRoleHeader, tc.role)
+			resp, body := doRequest(t, newApplicantTestApp(), req)
+			if resp.StatusCode != tc.status {
+				t.Fatalf("status = %d, want %d, body %s", resp.StatusCode, tc.status, body)
+			}
+
+			var stored models.Applicant
+			if err := database.DB.First(&stored, applicant.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			actions := auditActions(t, applicant.ID)
+			if tc.status != 200 {
+				if stored.Status != tc.from || len(actions) != 0 {
+					t.Errorf("stored status %s with audit %v, want %s unchanged and no audit", stored.Status, actions, tc.from)
+				}
+				return
+			}
+
+			if stored.Status != utils.StatusReviewed {
+				t.Errorf("stored status = %s, want reviewed", stored.Status)
+			}
+			var audit models.AuditLog
+			if err := database.DB.Where("applicant_id = ?", applicant.ID).First(&audit).Error; err != nil {
+				t.Fatal(err)
+			}
+			if audit.Action != "reopen" || audit.Reason != tc.body["reason"] || audit.Actor != "recruiter@example.com" {
+				t.Errorf("audit = %+v, want a reopen entry with the reason", audit)
+			}
+		})
+	}
+}
+
+func TestReopenApplicantConfigured(t *testing.T) {
+	useTestBackends(t)
+	cfg := defaultApplicantConfig()
+	cfg.ReopenStatus = utils.StatusPending
+	cfg.ReopenRoles = []string{"admin", "recruiter"}
+	useApplicantConfig(t, cfg)
+	applicant := createTestApplicant(t, models.Applicant{Status: utils.StatusHired})
+
+	// The roles are read when the routes are registered, after the config
+	req := newJSONRequest(t, "POST", fmt.Sprintf("/applicants/%d/reopen", applicant.ID), map[string]string{"reason": "Start over"})
+	resp, body := doRequest(t, newApplicantTestApp(), req)
+	if resp.StatusCode != 200 {
+		t.Fatalf("status = %d, body %s", resp.StatusCode, body)
+	}
+	var got models.Applicant
+	decodeJSON(t, body, &got)
+	if got.Status != utils.StatusPending {
+		t.Errorf("status = %s, want the configured pending", got.Status)
+	}
+}
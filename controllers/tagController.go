@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/events"
+	"job-tracker/middleware"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"log"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxTagLength is the longest tag name accepted, matching the tags.name column
+const maxTagLength = 50
+
+// errTagNotOnApplicant is returned when removing a tag the applicant doesn't have
+var errTagNotOnApplicant = errors.New("tag not on applicant")
+
+// AddTagsRequest is the body accepted by AddApplicantTags
+type AddTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// withTags preloads the tags of the applicants a query returns
+func withTags(db *gorm.DB) *gorm.DB {
+	return db.Preload("Tags")
+}
+
+// normalizeTag sanitizes and lowercases a tag name, so "Referral " and
+// "referral" are the same tag. The error leaves the subject out for the
+// caller to name.
+func normalizeTag(name string) (string, error) {
+	name = strings.ToLower(utils.SanitizeString(name))
+	if name == "" {
+		return "", fmt.Errorf("must not be empty")
+	}
+	if !utils.ValidateMaxLength(name, maxTagLength) {
+		return "", fmt.Errorf("must be at most %d characters", maxTagLength)
+	}
+	return name, nil
+}
+
+// normalizeTags normalizes a list of tag names and drops duplicates, keeping
+// the first occurrence
+func normalizeTags(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, raw := range names {
+		name, err := normalizeTag(raw)
+		if err != nil {
+			return nil, fmt.Errorf("tags %v", err)
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized, nil
+}
+
+// AddApplicantTags attaches tags to an applicant, creating tags that don't
+// exist yet. Tags the applicant already has are ignored, so the request can be
+// repeated safely.
+func AddApplicantTags(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var req AddTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "tags must be an array of strings"})
+	}
+	names, err := normalizeTags(req.Tags)
+	if err != nil {
+		return c.Status(422).JSON(fiber.Map{"error": err.Error(), "field": "tags"})
+	}
+	if len(names) == 0 {
+		return c.Status(422).JSON(fiber.Map{"error": "tags must contain at least one tag", "field": "tags"})
+	}
+	if len(names) > maxBatchSize {
+		return c.Status(422).JSON(fiber.Map{"error": fmt.Sprintf("tags must contain at most %d tags", maxBatchSize), "field": "tags"})
+	}
+
+	return saveApplicantTags(c, id, func(tx *gorm.DB, applicant *models.Applicant) error {
+		// Concurrent requests may create the same tag; the unique name keeps one
+		tags := make([]models.Tag, len(names))
+		for i, name := range names {
+			tags[i] = models.Tag{Name: name}
+		}
+		if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&tags).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("name IN ?", names).Find(&tags).Error; err != nil {
+			return err
+		}
+		return tx.Model(applicant).Association("Tags").Append(&tags)
+	})
+}
+
+// RemoveApplicantTag detaches one tag from an applicant. The tag itself is
+// kept for other applicants.
+func RemoveApplicantTag(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+	raw, err := url.PathUnescape(c.Params("tag"))
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid tag"})
+	}
+	name, err := normalizeTag(raw)
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "tag " + err.Error()})
+	}
+
+	return saveApplicantTags(c, id, func(tx *gorm.DB, applicant *models.Applicant) error {
+		for _, tag := range applicant.Tags {
+			if tag.Name == name {
+				return tx.Model(applicant).Association("Tags").Delete(&tag)
+			}
+		}
+		return errTagNotOnApplicant
+	})
+}
+
+// saveApplicantTags runs change on the locked applicant with its tags loaded,
+// audits and publishes the change when the tags differ, and responds with the
+// applicant
+func saveApplicantTags(c *fiber.Ctx, id uint, change func(tx *gorm.DB, applicant *models.Applicant) error) error {
+	var applicant models.Applicant
+	var changes map[string]models.FieldChange
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the row so concurrent tag changes are audited in order
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&applicant, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&applicant).Association("Tags").Find(&applicant.Tags); err != nil {
+			return err
+		}
+		before := applicant.Tags.Names()
+
+		if err := change(tx, &applicant); err != nil {
+			return err
+		}
+
+		applicant.Tags = nil
+		if err := tx.Model(&applicant).Association("Tags").Find(&applicant.Tags); err != nil {
+			return err
+		}
+		after := applicant.Tags.Names()
+		if reflect.DeepEqual(before, after) {
+			return nil
+		}
+
+		// Tags are part of the applicant's JSON, so conditional GETs and the
+		// updated_since feed must see the change
+		now := time.Now().UTC()
+		if err := tx.Model(&applicant).Update("updated_at", now).Error; err != nil {
+			return err
+		}
+		applicant.UpdatedAt = now
+
+		changes = map[string]models.FieldChange{"tags": {Old: before, New: after}}
+		action := "tag"
+		if len(after) < len(before) {
+			action = "untag"
+		}
+		return recordAudit(tx, applicant.ID, action, currentUser(c), changes, "")
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(404).JSON(fiber.Map{"error": "Applicant not found"})
+	}
+	if errors.Is(err, errTagNotOnApplicant) {
+		return c.Status(404).JSON(fiber.Map{"error": "Tag not found on applicant"})
+	}
+	if err != nil {
+		log.Printf("[%s] Database error changing tags of applicant %d: %v", middleware.RequestID(c), id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to update tags"})
+	}
+
+	if changes != nil {
+		invalidateApplicantListCache()
+		invalidateApplicantCache(applicant.ID)
+		publishEvent(events.ApplicantTagged, applicant, currentUser(c), changes)
+	}
+	return respondWithChanges(c, 200, applicant, changes)
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/models"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseTimezone reads the optional `tz` query parameter as an IANA zone name.
+// Without it timestamps stay in UTC, which is how they are stored.
+func parseTimezone(c *fiber.Ctx) (*time.Location, error) {
+	name := c.Query("tz")
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// localizeApplicants converts the response timestamps to the requested zone.
+// It only changes how the times are rendered, never the stored values.
+func localizeApplicants(applicants []models.Applicant, loc *time.Location) {
+	for i := range applicants {
+		localizeApplicant(&applicants[i], loc)
+	}
+}
+
+func localizeApplicant(applicant *models.Applicant, loc *time.Location) {
+	applicant.CreatedAt = applicant.CreatedAt.In(loc)
+	applicant.UpdatedAt = applicant.UpdatedAt.In(loc)
+	applicant.StatusChangedAt = applicant.StatusChangedAt.In(loc)
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/models"
+	"net/url"
+	"testing"
+	"time"
+	// Zone data for machines without a system zoneinfo database
+	_ "time/tzdata"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestParseTimezone(t *testing.T) {
+	tests := []struct {
+		tz   string
+		want string
+		err  bool
+	}{
+		{"", "UTC", false},
+		{"UTC", "UTC", false},
+		{"Europe/Paris", "Europe/Paris", false},
+		{"America/New_York", "America/New_York", false},
+		{"Mars/Olympus_Mons", "", true},
+		{"../../etc/passwd", "", true},
+	}
+	app := newTestApp()
+	app.Get("/", func(c *fiber.Ctx) error {
+		loc, err := parseTimezone(c)
+		if err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		}
+		return c.JSON(fiber.Map{"zone": loc.String()})
+	})
+	for _, tc := range tests {
+		resp, body := doRequest(t, app, newJSONRequest(t, "GET", "/?tz="+url.QueryEscape(tc.tz), nil))
+		var got struct {
+			Zone  string `json:"zone"`
+			Error string `json:"error"`
+		}
+		decodeJSON(t, body, &got)
+		if tc.err {
+			if resp.StatusCode != 400 || got.Error != fmt.Sprintf("unknown time zone %q", tc.tz) {
+				t.Errorf("tz %q: status %d, error %q, want 400 naming the zone", tc.tz, resp.StatusCode, got.Error)
+			}
+		} else if got.Zone != tc.want {
+			t.Errorf("tz %q: zone = %q, want %q", tc.tz, got.Zone, tc.want)
+		}
+	}
+}
+
+func TestApplicantTimestampsRenderedInZone(t *testing.T) {
+	// Instants either side of a DST change in each zone
+	tests := []struct {
+		tz   string
+		at   time.Time
+		want string
+	}{
+		{"", time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC), "2024-03-10T06:30:00Z"},
+		{"America/New_York", time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC), "2024-03-10T01:30:00-05:00"},
+		{"America/New_York", time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC), "2024-03-10T03:30:00-04:00"},
+		{"Europe/Paris", time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC), "2024-10-27T02:30:00+02:00"},
+		{"Europe/Paris", time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC), "2024-10-27T02:30:00+01:00"},
+		{"Asia/Kolkata", time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC), "2024-10-27T07:00:00+05:30"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.tz+" "+tc.want, func(t *testing.T) {
+			useTestBackends(t)
+			applicant := createTestApplicant(t, models.Applicant{StatusChangedAt: tc.at})
+			if err := database.DB.Model(&applicant).UpdateColumns(map[string]interface{}{
+				"created_at": tc.at, "updated_at": tc.at,
+			}).Error; err != nil {
+				t.Fatal(err)
+			}
+			app := newApplicantTestApp()
+
+			type timestamps struct {
+				CreatedAt       string `json:"created_at"`
+				UpdatedAt       string `json:"updated_at"`
+				StatusChangedAt string `json:"status_changed_at"`
+			}
+			check := func(where string, got timestamps) {
+				if got.CreatedAt != tc.want || got.UpdatedAt != tc.want || got.StatusChangedAt != tc.want {
+					t.Errorf("%s: timestamps = %+v, want %s", where, got, tc.want)
+				}
+			}
+
+			query := "?tz=" + url.QueryEscape(tc.tz)
+			_, body := doRequest(t, app, newJSONRequest(t, "GET", fmt.Sprintf("/applicants/%d%s", applicant.ID, query), nil))
+			var one timestamps
+			decodeJSON(t, body, &one)
+			check("get", one)
+
+			_, body = doRequest(t, app, newJSONRequest(t, "GET", "/applicants"+query, nil))
+			var list struct {
+				Data []timestamps `json:"data"`
+			}
+			decodeJSON(t, body, &list)
+			if len(list.Data) != 1 {
+				t.Fatalf("list returned %d applicants, want 1", len(list.Data))
+			}
+			check("list", list.Data[0])
+
+			// Rendering never changes what is stored
+			var stored models.Applicant
+			if err := database.DB.First(&stored, applicant.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			if !stored.CreatedAt.Equal(tc.at) || stored.CreatedAt.Location() != time.UTC {
+				t.Errorf("stored created_at = %v, want %v in UTC", stored.CreatedAt, tc.at)
+			}
+		})
+	}
+}
+
+func TestUnknownTimezoneReturns400(t *testing.T) {
+	useTestBackends(t)
+	applicant := createTestApplicant(t, models.Applicant{})
+	app := newApplicantTestApp()
+	for _, target := range []string{"/applicants?tz=Nowhere", fmt.Sprintf("/applicants/%d?tz=Nowhere", applicant.ID)} {
+		if resp, body := doRequest(t, app, newJSONRequest(t, "GET", target, nil)); resp.StatusCode != 400 {
+			t.Errorf("GET %s: status = %d, want 400, body %s", target, resp.StatusCode, body)
+		}
+	}
+}
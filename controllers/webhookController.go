@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"job-tracker/database"
+	"job-tracker/events"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"job-tracker/webhooks"
+	"log"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// WebhookRequest is the body accepted by CreateWebhook
+type WebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// webhookWithSecret is a created webhook with its signing secret, which is
+// only returned once
+type webhookWithSecret struct {
+	models.Webhook
+	Secret string `json:"secret"`
+}
+
+// CreateWebhook registers a URL to receive applicant events. events lists the
+// types to send; leaving it out or empty sends every type. The response holds
+// the secret the deliveries are signed with.
+func CreateWebhook(c *fiber.Ctx) error {
+	var req WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	req.URL = utils.SanitizeString(req.URL)
+	if !utils.ValidateURL(req.URL, maxURLLength) {
+		return c.Status(422).JSON(fiber.Map{
+			"error": fmt.Sprintf("url must be an http or https URL of at most %d characters", maxURLLength),
+			"field": "url",
+		})
+	}
+
+	types := models.EventTypes{}
+	seen := make(map[string]bool, len(req.Events))
+	for _, eventType := range req.Events {
+		if !events.IsType(eventType) {
+			return c.Status(422).JSON(fiber.Map{"error": fmt.Sprintf("unknown event type %q", eventType), "field": "events"})
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			types = append(types, eventType)
+		}
+	}
+	sort.Strings(types)
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		log.Printf("Failed to generate webhook secret: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create webhook"})
+	}
+
+	hook := models.Webhook{URL: req.URL, Events: types, Secret: secret}
+	if err := database.DB.Create(&hook).Error; err != nil {
+		log.Printf("Database error creating webhook: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to create webhook"})
+	}
+
+	log.Printf("User %s registered webhook %d for %s", currentUser(c), hook.ID, hook.URL)
+	return c.Status(201).JSON(webhookWithSecret{Webhook: hook, Secret: secret})
+}
+
+// ListWebhooks returns every registered webhook, without their secrets
+func ListWebhooks(c *fiber.Ctx) error {
+	hooks := []models.Webhook{}
+	if err := database.DB.Order("id ASC").Find(&hooks).Error; err != nil {
+		log.Printf("Database error fetching webhooks: %v", err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to fetch webhooks"})
+	}
+	return c.JSON(fiber.Map{"data": hooks})
+}
+
+// DeleteWebhook stops deliveries to a webhook. Deliveries already queued
+// still run.
+func DeleteWebhook(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid id"})
+	}
+
+	var hook models.Webhook
+	if err := database.DB.First(&hook, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(404).JSON(fiber.Map{"error": "Webhook not found"})
+		}
+		log.Printf("Database error loading webhook %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete webhook"})
+	}
+	if err := database.DB.Delete(&hook).Error; err != nil {
+		log.Printf("Database error deleting webhook %d: %v", id, err)
+		return c.Status(500).JSON(fiber.Map{"error": "Failed to delete webhook"})
+	}
+
+	log.Printf("User %s deleted webhook %d", currentUser(c), id)
+	return c.JSON(fiber.Map{"message": "Webhook deleted successfully"})
+}
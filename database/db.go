@@ -1,10 +1,10 @@
 package database
 
 import (
-	"fmt"
+	"job-tracker/config"
+	"job-tracker/metrics"
 	"job-tracker/models"
 	"log"
-	"os"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -14,20 +14,15 @@ import (
 
 var DB *gorm.DB //CONNECTION POINTER
 
-func ConnectDB() {
-	// Get database configuration from environment variables
-	host := getEnv("DB_HOST", "localhost")
-	user := getEnv("DB_USER", "postgres")
-	password := getEnv("DB_PASSWORD", "123")
-	dbname := getEnv("DB_NAME", "postgres")
-	port := getEnv("DB_PORT", "5432")
-
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
-		host, user, password, dbname, port)
+func ConnectDB(cfg config.DatabaseConfig) {
+	dbLogger := cfg.Logger
+	if dbLogger == nil {
+		dbLogger = logger.Default.LogMode(cfg.LogLevel)
+	}
 
 	// Configure GORM with better settings
-	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+	database, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{
+		Logger: dbLogger,
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
@@ -43,29 +38,67 @@ func ConnectDB() {
 	}
 
 	// Set connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
 	// Auto-migrate the schema
-	err = database.AutoMigrate(&models.Applicant{})
+	err = database.AutoMigrate(&models.Applicant{}, &models.AuditLog{}, &models.ApplicantStatusCount{}, &models.AuthEvent{}, &models.User{}, &models.StatusHistory{}, &models.Comment{}, &models.Tag{}, &models.Webhook{})
 	if err != nil {
 		log.Fatal("Failed to migrate database: ", err)
 	}
 
-	// Create indexes for better performance
-	database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_email ON applicants(email)")
-	database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_status ON applicants(status)")
-	database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_created_at ON applicants(created_at)")
+	// Backfill status timestamps for rows created before status tracking existed
+	database.Exec("UPDATE applicants SET status_changed_at = updated_at WHERE status_changed_at IS NULL")
+
+	// Create indexes for better performance. They only cover live rows: every
+	// query made through GORM's soft-delete scope filters on deleted_at IS NULL,
+	// so the planner can use them while tombstones stay out of the index.
+	database.Exec("DROP INDEX IF EXISTS idx_applicants_email, idx_applicants_status, idx_applicants_created_at")
+	database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_email_active ON applicants(email) WHERE deleted_at IS NULL")
+	database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_status_active ON applicants(status) WHERE deleted_at IS NULL")
+	database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_created_at_active ON applicants(created_at) WHERE deleted_at IS NULL")
+
+	// Status counts read by /applicants/stats instead of scanning the table
+	if err := setupStatusSummary(database); err != nil {
+		log.Fatal("Failed to set up applicant status summary: ", err)
+	}
+
+	// Trigram indexes backing the position suggestions and the list search
+	if err := database.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		log.Printf("Warning: pg_trgm extension unavailable, position suggestions disabled: %v", err)
+	} else {
+		database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_position_trgm ON applicants USING gin (position gin_trgm_ops)")
+		database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_name_trgm ON applicants USING gin (name gin_trgm_ops)")
+		database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_email_trgm ON applicants USING gin (email gin_trgm_ops)")
+		database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_notes_trgm ON applicants USING gin (notes gin_trgm_ops)")
+	}
+
+	// Full-text index for word matches in notes
+	database.Exec("CREATE INDEX IF NOT EXISTS idx_applicants_notes_fts ON applicants USING gin (to_tsvector('english', coalesce(notes, '')))")
 
 	DB = database
 	log.Println("Connected to database successfully")
 }
 
-// Helper function to get environment variable with default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// MonitorPool samples the connection pool stats on every tick and publishes them as metrics
+func MonitorPool(interval time.Duration) {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		log.Printf("Warning: pool metrics disabled: %v", err)
+		return
 	}
-	return defaultValue
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			stats := sqlDB.Stats()
+			metrics.DBOpenConnections.Set(float64(stats.OpenConnections))
+			metrics.DBInUse.Set(float64(stats.InUse))
+			metrics.DBIdle.Set(float64(stats.Idle))
+			metrics.DBWaitCount.Set(float64(stats.WaitCount))
+			metrics.DBWaitDuration.Set(stats.WaitDuration.Seconds())
+		}
+	}()
 }
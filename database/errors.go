@@ -0,0 +1,17 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+// IsUniqueViolation reports whether err was caused by a unique constraint,
+// such as two applicants with the same email
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	unique := &pgconn.PgError{Code: "23505", ConstraintName: "applicants_email_key"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unique violation", unique, true},
+		{"wrapped", fmt.Errorf("update applicant: %w", unique), true},
+		{"not null violation", &pgconn.PgError{Code: "23502"}, false},
+		{"other error", errors.New("duplicate key value violates unique constraint"), false},
+		{"nil", nil, false},
+	}
+	for _, tc := range tests {
+		if got := IsUniqueViolation(tc.err); got != tc.want {
+			t.Errorf("%s: IsUniqueViolation = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// statusCountsTrigger keeps applicant_status_counts in step with live
+// (non-soft-deleted) applicants. It runs in the writing transaction, so the
+// summary commits or rolls back together with the change that caused it.
+const statusCountsTrigger = `
+CREATE OR REPLACE FUNCTION sync_applicant_status_counts() RETURNS trigger AS $$
+BEGIN
+	IF TG_OP = 'UPDATE' AND OLD.status IS NOT DISTINCT FROM NEW.status
+		AND (OLD.deleted_at IS NULL) = (NEW.deleted_at IS NULL) THEN
+		RETURN NULL;
+	END IF;
+	IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.deleted_at IS NULL THEN
+		UPDATE applicant_status_counts SET count = count - 1 WHERE status = OLD.status;
+	END IF;
+	IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.deleted_at IS NULL THEN
+		INSERT INTO applicant_status_counts (status, count) VALUES (NEW.status, 1)
+		ON CONFLICT (status) DO UPDATE SET count = applicant_status_counts.count + 1;
+	END IF;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql`
+
+// setupStatusSummary installs the summary trigger and rebuilds the counts from
+// the applicants table, blocking writers while it does so
+func setupStatusSummary(db *gorm.DB) error {
+	if err := db.Exec(statusCountsTrigger).Error; err != nil {
+		return err
+	}
+	if err := db.Exec("DROP TRIGGER IF EXISTS applicants_status_counts ON applicants").Error; err != nil {
+		return err
+	}
+	if err := db.Exec(`CREATE TRIGGER applicants_status_counts
+		AFTER INSERT OR UPDATE OR DELETE ON applicants
+		FOR EACH ROW EXECUTE FUNCTION sync_applicant_status_counts()`).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE applicants IN SHARE MODE").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM applicant_status_counts").Error; err != nil {
+			return err
+		}
+		result := tx.Exec(`INSERT INTO applicant_status_counts (status, count)
+			SELECT status, COUNT(*) FROM applicants WHERE deleted_at IS NULL GROUP BY status`)
+		if result.Error != nil {
+			return result.Error
+		}
+		log.Printf("Applicant status summary rebuilt (%d statuses)", result.RowsAffected)
+		return nil
+	})
+}
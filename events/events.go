@@ -0,0 +1,113 @@
+// Package events publishes applicant lifecycle events to subscribers, such as
+// webhooks or the event log. Each subscriber picks the event types it wants
+// and is run on the background job queue, so a slow or failing subscriber
+// never delays a request and is retried on its own.
+package events
+
+import (
+	"context"
+	"fmt"
+	"job-tracker/jobs"
+	"job-tracker/models"
+	"log"
+	"sync"
+	"time"
+)
+
+// Event types
+const (
+	ApplicantCreated       = "applicant.created"
+	ApplicantUpdated       = "applicant.updated"
+	ApplicantStatusChanged = "applicant.status_changed"
+	ApplicantTagged        = "applicant.tagged"
+	ApplicantDeleted       = "applicant.deleted"
+)
+
+// Types lists every event type
+var Types = []string{ApplicantCreated, ApplicantUpdated, ApplicantStatusChanged, ApplicantTagged, ApplicantDeleted}
+
+// Event is something that happened to an applicant. Changes holds the delta
+// for the event, such as the old and new status; Applicant is the state after
+// the event, when there is one.
+type Event struct {
+	Type        string                        `json:"type"`
+	ApplicantID uint                          `json:"applicant_id"`
+	OccurredAt  time.Time                     `json:"occurred_at"`
+	Actor       string                        `json:"actor,omitempty"`
+	Changes     map[string]models.FieldChange `json:"changes,omitempty"`
+	Applicant   *models.Applicant             `json:"applicant,omitempty"`
+}
+
+// Handler receives an event. Returning an error retries it.
+type Handler func(ctx context.Context, event Event) error
+
+type subscription struct {
+	name    string
+	types   map[string]bool
+	handler Handler
+}
+
+// Dispatcher fans events out to the subscribers of their type
+type Dispatcher struct {
+	queue jobs.Queue
+
+	mu   sync.RWMutex
+	subs []subscription
+}
+
+// NewDispatcher creates a dispatcher that runs subscribers on the given queue
+func NewDispatcher(queue jobs.Queue) *Dispatcher {
+	return &Dispatcher{queue: queue}
+}
+
+// Subscribe registers a handler for the given event types, or for every type
+// when none are given. Unknown types are rejected.
+func (d *Dispatcher) Subscribe(name string, types []string, handler Handler) error {
+	wanted := make(map[string]bool, len(types))
+	for _, eventType := range types {
+		if !IsType(eventType) {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+		wanted[eventType] = true
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subs = append(d.subs, subscription{name: name, types: wanted, handler: handler})
+	return nil
+}
+
+// Publish queues the event for every subscriber of its type
+func (d *Dispatcher) Publish(event Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, sub := range d.subs {
+		if len(sub.types) > 0 && !sub.types[event.Type] {
+			continue
+		}
+		handler := sub.handler
+		job := jobs.Job{
+			Name: fmt.Sprintf("event:%s:%s:%d", sub.name, event.Type, event.ApplicantID),
+			Run: func(ctx context.Context) error {
+				return handler(ctx, event)
+			},
+		}
+		if err := d.queue.Enqueue(job); err != nil {
+			log.Printf("Failed to queue %s event for %s: %v", event.Type, sub.name, err)
+		}
+	}
+}
+
+// IsType reports whether eventType is a known event type
+func IsType(eventType string) bool {
+	for _, known := range Types {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
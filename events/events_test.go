@@ -0,0 +1,88 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"job-tracker/jobs"
+	"strings"
+	"testing"
+)
+
+// runQueue runs each job as soon as it is queued and keeps its name
+type runQueue struct {
+	names []string
+}
+
+func (q *runQueue) Enqueue(job jobs.Job) error {
+	q.names = append(q.names, job.Name)
+	return job.Run(context.Background())
+}
+
+// recorder is a handler that keeps the type of every event it receives
+type recorder struct {
+	types []string
+}
+
+func (r *recorder) handle(ctx context.Context, event Event) error {
+	r.types = append(r.types, event.Type)
+	return nil
+}
+
+func TestSubscribersReceiveTheirTypes(t *testing.T) {
+	queue := &runQueue{}
+	d := NewDispatcher(queue)
+	var all, status, tagged recorder
+	if err := d.Subscribe("all", nil, all.handle); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Subscribe("status", []string{ApplicantStatusChanged}, status.handle); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Subscribe("tags", []string{ApplicantTagged, ApplicantDeleted}, tagged.handle); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, eventType := range Types {
+		d.Publish(Event{Type: eventType, ApplicantID: 7})
+	}
+
+	if fmt.Sprint(all.types) != fmt.Sprint(Types) {
+		t.Errorf("all received %v, want %v", all.types, Types)
+	}
+	if fmt.Sprint(status.types) != fmt.Sprint([]string{ApplicantStatusChanged}) {
+		t.Errorf("status received %v, want only %s", status.types, ApplicantStatusChanged)
+	}
+	if fmt.Sprint(tagged.types) != fmt.Sprint([]string{ApplicantTagged, ApplicantDeleted}) {
+		t.Errorf("tags received %v, want %s and %s", tagged.types, ApplicantTagged, ApplicantDeleted)
+	}
+	if len(queue.names) != len(Types)+3 || queue.names[0] != "event:all:applicant.created:7" {
+		t.Errorf("queued jobs = %v, want one per delivery named by subscriber, type and applicant", queue.names)
+	}
+}
+
+func TestPublishStampsOccurredAt(t *testing.T) {
+	d := NewDispatcher(&runQueue{})
+	var got Event
+	if err := d.Subscribe("log", nil, func(ctx context.Context, event Event) error {
+		got = event
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	d.Publish(Event{Type: ApplicantCreated, ApplicantID: 1})
+	if got.OccurredAt.IsZero() || got.OccurredAt.Location().String() != "UTC" {
+		t.Errorf("OccurredAt = %v, want the publish time in UTC", got.OccurredAt)
+	}
+}
+
+func TestSubscribeRejectsUnknownTypes(t *testing.T) {
+	d := NewDispatcher(&runQueue{})
+	err := d.Subscribe("hook", []string{ApplicantCreated, "applicant.archived"}, func(ctx context.Context, event Event) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "applicant.archived") {
+		t.Errorf("Subscribe error = %v, want one naming applicant.archived", err)
+	}
+	if !IsType(ApplicantTagged) || IsType("applicant") {
+		t.Error("IsType does not match the known types")
+	}
+}
@@ -0,0 +1,155 @@
+// Package jobs runs background work on an in-process worker pool so handlers
+// can hand off slow tasks, such as sending emails, without delaying the response
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"job-tracker/config"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueFull is returned by Enqueue when the queue has no free slot
+	ErrQueueFull = errors.New("job queue is full")
+	// ErrStopped is returned by Enqueue once the pool has begun shutting down
+	ErrStopped = errors.New("job queue is stopped")
+)
+
+// Job is a unit of background work. Run is retried when it returns an error.
+type Job struct {
+	// Name identifies the job in logs
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Queue accepts jobs for background processing
+type Queue interface {
+	// Enqueue schedules a job without blocking, failing if it can't be accepted
+	Enqueue(job Job) error
+}
+
+// Pool is a fixed set of workers reading from a bounded queue
+type Pool struct {
+	cfg     config.JobsConfig
+	queue   chan Job
+	workers sync.WaitGroup
+
+	// mu guards stopped and the closing of queue against concurrent Enqueue calls
+	mu      sync.RWMutex
+	stopped bool
+
+	// ctx is cancelled when a shutdown runs out of time, aborting running jobs
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewPool creates a pool and starts its workers
+func NewPool(cfg config.JobsConfig) *Pool {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pool{
+		cfg:    cfg,
+		queue:  make(chan Job, cfg.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for i := 0; i < cfg.Workers; i++ {
+		p.workers.Add(1)
+		go p.work()
+	}
+	return p
+}
+
+// Enqueue adds a job to the queue without blocking
+func (p *Pool) Enqueue(job Job) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.stopped {
+		return ErrStopped
+	}
+
+	select {
+	case p.queue <- job:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting jobs and waits for the queued ones to finish. If ctx
+// ends first, running jobs are cancelled, the remaining ones are dropped and
+// Shutdown returns without waiting further.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.stopped {
+		p.stopped = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+}
+
+// work runs queued jobs until the queue is closed and drained
+func (p *Pool) work() {
+	defer p.workers.Done()
+	for job := range p.queue {
+		if p.ctx.Err() != nil {
+			log.Printf("Job %s dropped: queue shut down before it ran", job.Name)
+			continue
+		}
+		p.run(job)
+	}
+}
+
+// run executes a job, retrying with exponential backoff and logging it as a
+// dead letter once every attempt has failed
+func (p *Pool) run(job Job) {
+	backoff := p.cfg.RetryBackoff
+	var err error
+	for attempt := 1; attempt <= p.cfg.MaxAttempts; attempt++ {
+		if err = p.runOnce(job); err == nil {
+			return
+		}
+		if attempt == p.cfg.MaxAttempts {
+			break
+		}
+
+		log.Printf("Job %s failed (attempt %d of %d), retrying in %s: %v", job.Name, attempt, p.cfg.MaxAttempts, backoff, err)
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-p.ctx.Done():
+			log.Printf("Dead letter: job %s abandoned during shutdown after %d attempts: %v", job.Name, attempt, err)
+			return
+		}
+	}
+	log.Printf("Dead letter: job %s failed after %d attempts: %v", job.Name, p.cfg.MaxAttempts, err)
+}
+
+// runOnce runs a job a single time, turning a panic into an error so one bad
+// job can't take down a worker
+func (p *Pool) runOnce(job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(p.ctx)
+}
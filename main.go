@@ -1,10 +1,25 @@
 package main
 
 import (
+	"context"
+	"job-tracker/apidog"
+	"job-tracker/auth"
+	"job-tracker/config"
+	"job-tracker/controllers"
 	"job-tracker/database"
+	"job-tracker/jobs"
+	"job-tracker/metrics"
+	"job-tracker/middleware"
+	"job-tracker/notifications"
+	"job-tracker/pii"
 	"job-tracker/routes"
+	"job-tracker/workflow"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // Embed the zone database for the tz query parameter; the runtime image may not ship one
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -12,58 +27,168 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/recover"
 )
 
+// Build metadata, injected at build time with -ldflags (see the Makefile)
+var (
+	version   = "dev"
+	commit    = "unknown"
+	buildDate = "unknown"
+)
+
 func main() {
-	// Get port from environment or use default
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "3000"
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
 	}
 
-	app := fiber.New(fiber.Config{
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			log.Printf("Error occurred: %v", err) // Add logging for debugging
-			return c.Status(code).JSON(fiber.Map{
-				"error": err.Error(),
-				"path":  c.Path(),
-			})
-		},
-	})
+	// Fail at boot rather than on the first status change if a template is missing
+	if err := notifications.LoadTemplates(cfg.Notifications); err != nil {
+		log.Fatal("Failed to load email templates: ", err)
+	}
+
+	// Likewise refuse to start with an invalid workflow rule
+	if err := workflow.LoadRules(cfg.Workflow.RulesFile); err != nil {
+		log.Fatal("Failed to load workflow rules: ", err)
+	}
+
+	pii.Configure(cfg.PII)
+	auth.Configure(cfg.Auth)
 
-	// Middleware setup
+	app := fiber.New(appConfig(cfg))
+
+	// Middleware setup. Metrics come first so every route, and the time
+	// spent in the other middleware, is measured.
+	app.Use(metrics.Middleware())
 	app.Use(recover.New()) // Add panic recovery
 	app.Use(logger.New(logger.Config{
 		Format: "[${time}] ${status} - ${method} ${path} - ${latency}\n",
 	}))
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,PATCH,DELETE,OPTIONS",
+		AllowHeaders:  "Origin,Content-Type,Accept,Authorization",
+		ExposeHeaders: "X-Request-ID,X-Total-Count,X-RateLimit-Limit,X-RateLimit-Remaining,X-RateLimit-Reset,X-RateLimit-Warning",
 	}))
+	app.Use(middleware.Maintenance(cfg.Maintenance))
+	if cfg.Maintenance.Mode != config.MaintenanceOff {
+		log.Printf("Maintenance mode %q is on", cfg.Maintenance.Mode)
+	}
+
+	// Landing response for the base URL
+	app.Get("/", rootHandler(cfg.Info))
 
 	// Health check endpoint
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
 			"status":  "healthy",
 			"service": "job-tracker",
-			"version": "1.0.0",
+			"version": version,
 		})
 	})
 
+	// Liveness only confirms the process is up; readiness pings Postgres and Redis
+	app.Get("/health/live", controllers.HealthLive)
+	app.Get("/health/ready", controllers.HealthReady)
+
+	// Build metadata of the running binary
+	app.Get("/version", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{
+			"version":    version,
+			"commit":     commit,
+			"build_date": buildDate,
+		})
+	})
+
+	// API reference: the OpenAPI document and a Swagger UI page for it
+	app.Get("/openapi.json", apidog.SpecHandler)
+	app.Get("/docs", apidog.DocsHandler)
+
 	// Connect to database
 	log.Println("Connecting to database...")
-	database.ConnectDB()
+	database.ConnectDB(cfg.Database)
+	database.MonitorPool(15 * time.Second)
+
+	// Prometheus metrics endpoint
+	app.Get("/metrics", metrics.Handler())
+
+	// Background workers for emails and other async work
+	queue := jobs.NewPool(cfg.Jobs)
 
 	// Setup routes
 	log.Println("Setting up routes...")
-	routes.Setup(app)
+	routes.Setup(app, cfg, queue)
 
 	// Start server
-	log.Printf("Starting server on port %s...", port)
-	if err := app.Listen(":" + port); err != nil {
-		log.Fatal("Failed to start server:", err)
+	go func() {
+		log.Printf("Starting %s server on port %s...", cfg.Server.Env, cfg.Server.Port)
+		if err := app.Listen(":" + cfg.Server.Port); err != nil {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
+	// On SIGINT or SIGTERM stop taking requests, then let queued jobs finish
+	stop, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	<-stop.Done()
+
+	log.Println("Shutting down...")
+	if err := app.ShutdownWithTimeout(10 * time.Second); err != nil {
+		log.Printf("Error shutting down server: %v", err)
+	}
+
+	drain, cancelDrain := context.WithTimeout(context.Background(), cfg.Jobs.DrainTimeout)
+	defer cancelDrain()
+	if err := queue.Shutdown(drain); err != nil {
+		log.Printf("Background jobs did not finish before the drain timeout: %v", err)
+	}
+	log.Println("Shutdown complete")
+}
+
+// appConfig is the Fiber configuration: the trailing-slash policy, the body
+// limit and the JSON error handler
+func appConfig(cfg *config.Config) fiber.Config {
+	return fiber.Config{
+		// Trailing-slash policy: both forms of a path reach the same handler, so
+		// /applicants, /applicants/, /applicants/1 and /applicants/1/ all work and
+		// nothing is redirected. Keep this explicit rather than relying on the default.
+		StrictRouting: false,
+		// Leave room for a full-size upload plus its multipart framing
+		BodyLimit: bodyLimit(cfg.Upload),
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			code := fiber.StatusInternalServerError
+			if e, ok := err.(*fiber.Error); ok {
+				code = e.Code
+			}
+			log.Printf("Error occurred: %v", err) // Add logging for debugging
+			return c.Status(code).JSON(fiber.Map{
+				"error": err.Error(),
+				"path":  c.Path(),
+			})
+		},
+	}
+}
+
+// rootHandler returns the landing response for the base URL. It is rebuilt on
+// every request and never cached so it always matches the running build.
+func rootHandler(info config.InfoConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		body := fiber.Map{
+			"name":    info.Name,
+			"version": version,
+			"routes":  []string{"/health", "/version", "/metrics", "/docs", "/openapi.json", "/auth", "/applicants", "/me", "/admin"},
+		}
+		if info.DocsURL != "" {
+			body["docs"] = info.DocsURL
+		}
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return c.JSON(body)
+	}
+}
+
+// bodyLimit is Fiber's default body limit, raised when UPLOAD_MAX_BYTES needs more
+func bodyLimit(upload config.UploadConfig) int {
+	limit := int(upload.MaxRequestBytes())
+	if limit < fiber.DefaultBodyLimit {
+		return fiber.DefaultBodyLimit
 	}
+	return limit
 }
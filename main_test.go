@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"job-tracker/config"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestTrailingSlashPolicy(t *testing.T) {
+	app := fiber.New(appConfig(&config.Config{}))
+	// The same shape as the /applicants group in routes.Setup
+	api := app.Group("/applicants")
+	api.Get("/", func(c *fiber.Ctx) error { return c.SendString("list") })
+	api.Post("/", func(c *fiber.Ctx) error { return c.SendString("create") })
+	api.Get("/:id", func(c *fiber.Ctx) error { return c.SendString("get " + c.Params("id")) })
+	api.Get("/:id/history", func(c *fiber.Ctx) error { return c.SendString("history " + c.Params("id")) })
+
+	tests := []struct {
+		method, path, want string
+	}{
+		{"GET", "/applicants", "list"},
+		{"GET", "/applicants/", "list"},
+		{"POST", "/applicants", "create"},
+		{"POST", "/applicants/", "create"},
+		{"GET", "/applicants/7", "get 7"},
+		{"GET", "/applicants/7/", "get 7"},
+		{"GET", "/applicants/7/history", "history 7"},
+		{"GET", "/applicants/7/history/", "history 7"},
+	}
+	for _, tc := range tests {
+		resp, err := app.Test(httptest.NewRequest(tc.method, tc.path, nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		// Both forms are served directly; nothing is redirected
+		if resp.StatusCode != 200 || string(body) != tc.want {
+			t.Errorf("%s %s = %d %q, want 200 %q", tc.method, tc.path, resp.StatusCode, body, tc.want)
+		}
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/applicants/7/missing/", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 404 || string(body) != `{"error":"Cannot GET /applicants/7/missing/","path":"/applicants/7/missing/"}` {
+		t.Errorf("unknown path = %d %s, want the JSON 404", resp.StatusCode, body)
+	}
+}
+
+func TestRootResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		info config.InfoConfig
+		docs interface{}
+	}{
+		{"with docs", config.InfoConfig{Name: "Job Tracker API", DocsURL: "/docs"}, "/docs"},
+		{"without docs", config.InfoConfig{Name: "Careers"}, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New(appConfig(&config.Config{}))
+			app.Get("/", rootHandler(tc.info))
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != 200 || resp.Header.Get(fiber.HeaderCacheControl) != "no-store" {
+				t.Fatalf("status %d, Cache-Control %q, want 200 and no-store", resp.StatusCode, resp.Header.Get(fiber.HeaderCacheControl))
+			}
+
+			var got map[string]interface{}
+			if err := json.Unmarshal(body, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got["name"] != tc.info.Name || got["version"] != version || got["docs"] != tc.docs {
+				t.Errorf("body = %s", body)
+			}
+			routes, _ := got["routes"].([]interface{})
+			want := map[string]bool{"/health": true, "/version": true, "/docs": true, "/applicants": true}
+			for _, route := range routes {
+				delete(want, route.(string))
+			}
+			if len(want) != 0 {
+				t.Errorf("routes %v are missing %v", routes, want)
+			}
+			if tc.docs == nil {
+				if _, ok := got["docs"]; ok {
+					t.Errorf("docs is set without a DocsURL: %s", body)
+				}
+			}
+		})
+	}
+}
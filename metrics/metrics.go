@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Database connection pool gauges, sampled periodically from sql.DB.Stats
+var (
+	DBOpenConnections = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_pool_open_connections",
+		Help: "Number of established connections, both in use and idle",
+	})
+	DBInUse = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_pool_in_use_connections",
+		Help: "Number of connections currently in use",
+	})
+	DBIdle = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_pool_idle_connections",
+		Help: "Number of idle connections",
+	})
+	DBWaitCount = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_pool_wait_count",
+		Help: "Total number of connections waited for",
+	})
+	DBWaitDuration = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "db_pool_wait_duration_seconds",
+		Help: "Total time blocked waiting for a new connection",
+	})
+)
+
+// RequestDuration tracks the p50/p95/p99 latency of every route over a
+// rolling ten-minute window. Routes are labelled by their pattern, such as
+// /applicants/:id, so the number of series stays bounded.
+var RequestDuration = promauto.NewSummaryVec(prometheus.SummaryOpts{
+	Name:       "http_request_duration_seconds",
+	Help:       "Request latency by method and route over a rolling window",
+	Objectives: map[float64]float64{0.5: 0.05, 0.95: 0.01, 0.99: 0.001},
+	MaxAge:     10 * time.Minute,
+	AgeBuckets: 5,
+}, []string{"method", "route"})
+
+// HTTPRequests counts every request by method, route pattern and status
+// code, for request and error rates
+var HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "http_requests_total",
+	Help: "Number of HTTP requests by method, route and status code",
+}, []string{"method", "route", "status"})
+
+// HTTPRequestLatency is a histogram of request latency by method, route
+// pattern and status code. Unlike RequestDuration it can be aggregated across
+// instances.
+var HTTPRequestLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "http_request_latency_seconds",
+	Help:    "Request latency by method, route and status code",
+	Buckets: prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+// CacheLookups counts applicant list cache lookups by result, hit or miss
+var CacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "applicant_cache_lookups_total",
+	Help: "Number of applicant list cache lookups by result",
+}, []string{"result"})
+
+// CacheHitRatio is the share of applicant list cache lookups served from the
+// cache since the process started
+var CacheHitRatio = promauto.NewGauge(prometheus.GaugeOpts{
+	Name: "applicant_cache_hit_ratio",
+	Help: "Share of applicant list cache lookups that were hits since start",
+})
+
+// Middleware records the count and latency of every request. It belongs
+// first in the chain, so the time spent in all other middleware is measured
+// and errors are recorded with the status the error handler will send.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		elapsed := time.Since(start).Seconds()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			status = fiber.StatusInternalServerError
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			}
+		}
+
+		// Routes are labelled by their pattern so the number of series stays bounded
+		method, route, code := c.Method(), c.Route().Path, strconv.Itoa(status)
+		HTTPRequests.WithLabelValues(method, route, code).Inc()
+		HTTPRequestLatency.WithLabelValues(method, route, code).Observe(elapsed)
+		RequestDuration.WithLabelValues(method, route).Observe(elapsed)
+		return err
+	}
+}
+
+// CacheErrors counts failed list cache operations by kind: read and write are
+// Redis errors, encode and decode are JSON errors on the cached value
+var CacheErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "applicant_cache_errors_total",
+	Help: "Number of failed applicant list cache operations",
+}, []string{"operation"})
+
+// Handler serves the registered metrics in the Prometheus text format
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.Handler())
+}
@@ -1,12 +1,18 @@
 package middleware
 
 import (
+	"errors"
+	"job-tracker/auth"
+	"job-tracker/authaudit"
 	"strings"
+
 	"github.com/gofiber/fiber/v2"
 )
 
-// SimpleAuth is a basic authentication middleware
-func SimpleAuth() fiber.Handler {
+// JWTAuth authenticates requests with a Bearer JWT, setting user_id and
+// user_role from its claims. Expired tokens and bad signatures get their own
+// 401 messages so clients know whether to log in again.
+func JWTAuth() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Skip auth for health check
 		if c.Path() == "/health" {
@@ -14,32 +20,57 @@ func SimpleAuth() fiber.Handler {
 		}
 
 		// Get Authorization header
-		auth := c.Get("Authorization")
-		if auth == "" {
+		header := c.Get("Authorization")
+		if header == "" {
 			return c.Status(401).JSON(fiber.Map{
 				"error": "Authorization header required",
 			})
 		}
 
 		// Check if it's a Bearer token
-		if !strings.HasPrefix(auth, "Bearer ") {
+		if !strings.HasPrefix(header, "Bearer ") {
+			authaudit.Record(c, authaudit.TokenRejected, "", false, "invalid authorization format")
 			return c.Status(401).JSON(fiber.Map{
 				"error": "Invalid authorization format",
 			})
 		}
 
-		// Simple token validation (in real app, validate against database)
-		token := strings.TrimPrefix(auth, "Bearer ")
-		if token == "" || len(token) < 10 {
+		token := strings.TrimPrefix(header, "Bearer ")
+		claims, err := auth.Parse(token)
+		if err != nil {
+			authaudit.Record(c, authaudit.TokenRejected, authaudit.Fingerprint(token), false, err.Error())
+			message := "Invalid token"
+			switch {
+			case errors.Is(err, auth.ErrTokenExpired):
+				message = "Token has expired"
+			case errors.Is(err, auth.ErrInvalidSignature):
+				message = "Invalid token signature"
+			}
 			return c.Status(401).JSON(fiber.Map{
-				"error": "Invalid token",
+				"error": message,
 			})
 		}
 
-		// Add user info to context (simplified)
-		c.Locals("user_id", "user_123")
-		c.Locals("user_role", "admin")
+		c.Locals("user_id", claims.UserID)
+		c.Locals("user_role", claims.Role)
+		authaudit.Record(c, authaudit.TokenUsed, claims.UserID, true, "")
 
 		return c.Next()
 	}
 }
+
+// RequireRole allows the request only if the authenticated user has one of the
+// given roles. It must run after an authentication middleware sets user_role.
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("user_role").(string)
+		for _, allowed := range roles {
+			if role == allowed {
+				return c.Next()
+			}
+		}
+		return c.Status(403).JSON(fiber.Map{
+			"error": "Insufficient permissions",
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"job-tracker/config"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Maintenance turns requests away with 503 and Retry-After while the API is in
+// maintenance mode. In read_only mode reads are still served and only writes
+// are rejected. Paths in the allowlist, and anything below them, stay up in
+// every mode so health checks keep passing during a deploy.
+func Maintenance(cfg config.MaintenanceConfig) fiber.Handler {
+	retryAfter := strconv.Itoa(int(cfg.RetryAfter.Seconds()))
+
+	return func(c *fiber.Ctx) error {
+		if cfg.Mode == config.MaintenanceOff || maintenanceAllowed(c.Path(), cfg.AllowPaths) {
+			return c.Next()
+		}
+		if cfg.Mode == config.MaintenanceReadOnly {
+			switch c.Method() {
+			case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+				return c.Next()
+			}
+		}
+
+		c.Set(fiber.HeaderRetryAfter, retryAfter)
+		return c.Status(503).JSON(fiber.Map{
+			"error":       cfg.Message,
+			"maintenance": cfg.Mode,
+		})
+	}
+}
+
+// maintenanceAllowed reports whether path is one of the allowed paths or below one
+func maintenanceAllowed(path string, allowed []string) bool {
+	path = strings.TrimSuffix(path, "/")
+	for _, prefix := range allowed {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"job-tracker/config"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newMaintenanceApp creates an app with health, version and applicant routes
+// behind the maintenance middleware in the given mode
+func newMaintenanceApp(mode string) *fiber.App {
+	app := fiber.New()
+	app.Use(Maintenance(config.MaintenanceConfig{
+		Mode:       mode,
+		Message:    "Down for a deploy",
+		AllowPaths: []string{"/health", "/version/"},
+		RetryAfter: 2 * time.Minute,
+	}))
+	ok := func(c *fiber.Ctx) error { return c.SendString("ok") }
+	app.Get("/health", ok)
+	app.Get("/health/ready", ok)
+	app.Get("/healthz", ok)
+	app.Get("/version", ok)
+	app.Get("/applicants", ok)
+	app.Head("/applicants", ok)
+	app.Post("/applicants", ok)
+	app.Delete("/applicants/:id", ok)
+	return app
+}
+
+func TestMaintenanceModes(t *testing.T) {
+	requests := []struct{ method, path string }{
+		{"GET", "/health"},
+		{"GET", "/health/ready"},
+		{"GET", "/version"},
+		{"GET", "/healthz"},
+		{"GET", "/applicants"},
+		{"HEAD", "/applicants"},
+		{"POST", "/applicants"},
+		{"DELETE", "/applicants/1"},
+	}
+	want := map[string][]int{
+		config.MaintenanceOff:      {200, 200, 200, 200, 200, 200, 200, 200},
+		config.MaintenanceReadOnly: {200, 200, 200, 200, 200, 200, 503, 503},
+		config.MaintenanceOn:       {200, 200, 200, 503, 503, 503, 503, 503},
+	}
+	for mode, statuses := range want {
+		t.Run(mode, func(t *testing.T) {
+			app := newMaintenanceApp(mode)
+			for i, r := range requests {
+				resp, err := app.Test(httptest.NewRequest(r.method, r.path, nil), -1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if resp.StatusCode != statuses[i] {
+					t.Errorf("%s %s: status = %d, want %d", r.method, r.path, resp.StatusCode, statuses[i])
+				}
+				retryAfter := resp.Header.Get(fiber.HeaderRetryAfter)
+				if (resp.StatusCode == 503) != (retryAfter == "120") {
+					t.Errorf("%s %s: status %d with Retry-After %q", r.method, r.path, resp.StatusCode, retryAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestMaintenanceResponseBody(t *testing.T) {
+	resp, err := newMaintenanceApp(config.MaintenanceOn).Test(httptest.NewRequest("GET", "/applicants", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	var got map[string]string
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("decode %q: %v", body, err)
+	}
+	if got["error"] != "Down for a deploy" || got["maintenance"] != config.MaintenanceOn {
+		t.Errorf("body = %v, want the configured message and mode", got)
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"job-tracker/quota"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Quota enforces the per-user daily and monthly request quotas. It must run
+// after an authentication middleware; anonymous requests are not counted. If
+// Redis is unavailable the request is allowed, since quotas are a billing
+// concern rather than a protection against overload.
+//
+// Every counted response, including the 429 that trips the limit, carries
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset (seconds
+// until the window resets) for the window closest to running out. Once less
+// than warnBelow of it is left, X-RateLimit-Warning is added so clients can
+// back off early.
+func Quota(tracker *quota.Tracker, warnBelow float64) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, _ := c.Locals("user_id").(string)
+		if userID == "" {
+			return c.Next()
+		}
+		role, _ := c.Locals("user_role").(string)
+
+		usage, err := tracker.Consume(c.UserContext(), userID, role)
+		if err != nil {
+			log.Printf("Warning: quota check skipped for %s: %v", userID, err)
+			return c.Next()
+		}
+
+		if window, ok := usage.Binding(); ok {
+			c.Set("X-RateLimit-Limit", strconv.FormatInt(window.Limit, 10))
+			c.Set("X-RateLimit-Remaining", strconv.FormatInt(window.Remaining, 10))
+			c.Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(time.Until(window.ResetAt).Seconds()))))
+			if float64(window.Remaining) < warnBelow*float64(window.Limit) {
+				c.Set("X-RateLimit-Warning", "Request quota nearly used up")
+			}
+		}
+
+		for _, window := range []quota.Window{usage.Daily, usage.Monthly} {
+			if window.Exceeded() {
+				retryAfter := int(math.Ceil(time.Until(window.ResetAt).Seconds()))
+				c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+				return c.Status(429).JSON(fiber.Map{
+					"error":    "Request quota exceeded",
+					"reset_at": window.ResetAt,
+				})
+			}
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"job-tracker/quota"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+)
+
+// newQuotaApp creates an app that authenticates every request as ada with
+// the given role and enforces the daily limits
+func newQuotaApp(t *testing.T, server *miniredis.Miniredis, role string, daily quota.Limits) *fiber.App {
+	t.Helper()
+	return newQuotaAppWithMonthly(t, server, role, daily, quota.Limits{})
+}
+
+// newQuotaAppWithMonthly is newQuotaApp with monthly limits as well
+func newQuotaAppWithMonthly(t *testing.T, server *miniredis.Miniredis, role string, daily, monthly quota.Limits) *fiber.App {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	tracker := quota.NewTracker(client, daily, monthly)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", "ada@example.com")
+		c.Locals("user_role", role)
+		return c.Next()
+	})
+	app.Use(Quota(tracker, 0.5))
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	return app
+}
+
+func TestQuotaRejectsWhenExhausted(t *testing.T) {
+	server := miniredis.RunT(t)
+	app := newQuotaApp(t, server, "recruiter", quota.Limits{"default": 2})
+
+	statuses := []int{}
+	var last, previous map[string]string
+	for i := 0; i < 3; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		statuses = append(statuses, resp.StatusCode)
+		previous, last = last, map[string]string{
+			"remaining":   resp.Header.Get("X-RateLimit-Remaining"),
+			"warning":     resp.Header.Get("X-RateLimit-Warning"),
+			"retry_after": resp.Header.Get(fiber.HeaderRetryAfter),
+		}
+	}
+	if statuses[0] != 200 || statuses[1] != 200 || statuses[2] != 429 {
+		t.Fatalf("statuses = %v, want 200, 200, 429", statuses)
+	}
+	if previous["remaining"] != "0" || previous["warning"] == "" {
+		t.Errorf("last allowed request headers = %v, want 0 remaining with a warning", previous)
+	}
+	if retry, err := strconv.Atoi(last["retry_after"]); err != nil || retry <= 0 || retry > 24*60*60 {
+		t.Errorf("Retry-After = %q, want seconds until midnight", last["retry_after"])
+	}
+}
+
+func TestQuotaUnlimitedRole(t *testing.T) {
+	server := miniredis.RunT(t)
+	app := newQuotaApp(t, server, "admin", quota.Limits{"default": 1, "admin": 0})
+	for i := 0; i < 5; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != 200 || resp.Header.Get("X-RateLimit-Limit") != "" {
+			t.Fatalf("request %d: status %d, limit header %q, want 200 without quota headers",
+				i+1, resp.StatusCode, resp.Header.Get("X-RateLimit-Limit"))
+		}
+	}
+}
+
+func TestQuotaAllowsRequestsWhenRedisIsDown(t *testing.T) {
+	server := miniredis.RunT(t)
+	app := newQuotaApp(t, server, "recruiter", quota.Limits{"default": 1})
+	server.Close()
+	for i := 0; i < 3; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != 200 {
+			t.Fatalf("request %d: status = %d, want 200 with the quota skipped", i+1, resp.StatusCode)
+		}
+	}
+}
+
+func TestQuotaHeadersDecrement(t *testing.T) {
+	server := miniredis.RunT(t)
+	app := newQuotaApp(t, server, "recruiter", quota.Limits{"default": 4})
+	untilMidnight := time.Until(time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour))
+
+	wantRemaining := []string{"3", "2", "1", "0", "0"}
+	wantWarning := []bool{false, false, true, true, true}
+	for i, remaining := range wantRemaining {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantStatus := 200
+		if i == len(wantRemaining)-1 {
+			wantStatus = 429
+		}
+		if resp.StatusCode != wantStatus {
+			t.Errorf("request %d: status = %d, want %d", i+1, resp.StatusCode, wantStatus)
+		}
+		if got := resp.Header.Get("X-RateLimit-Limit"); got != "4" {
+			t.Errorf("request %d: X-RateLimit-Limit = %q, want 4", i+1, got)
+		}
+		if got := resp.Header.Get("X-RateLimit-Remaining"); got != remaining {
+			t.Errorf("request %d: X-RateLimit-Remaining = %q, want %s", i+1, got, remaining)
+		}
+		reset, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Reset"))
+		if err != nil || reset <= 0 || time.Duration(reset)*time.Second > untilMidnight+time.Second {
+			t.Errorf("request %d: X-RateLimit-Reset = %q, want seconds until midnight UTC", i+1, resp.Header.Get("X-RateLimit-Reset"))
+		}
+		if got := resp.Header.Get("X-RateLimit-Warning") != ""; got != wantWarning[i] {
+			t.Errorf("request %d: warning sent = %v, want %v", i+1, got, wantWarning[i])
+		}
+	}
+}
+
+func TestQuotaHeadersReportBindingWindow(t *testing.T) {
+	server := miniredis.RunT(t)
+	app := newQuotaAppWithMonthly(t, server, "recruiter", quota.Limits{"default": 10}, quota.Limits{"default": 3})
+
+	for i, want := range []string{"2", "1", "0"} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		limit, remaining := resp.Header.Get("X-RateLimit-Limit"), resp.Header.Get("X-RateLimit-Remaining")
+		if limit != "3" || remaining != want {
+			t.Errorf("request %d: limit %q, remaining %q, want the monthly window 3, %s", i+1, limit, remaining, want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"job-tracker/ratelimit"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimit limits the requests each client IP may make, answering 429 with
+// Retry-After once the limit is reached. Paths in exempt, and anything below
+// them, are never limited. If Redis is unavailable the request is allowed, so
+// a cache outage doesn't take the API down with it.
+func RateLimit(limiter *ratelimit.Limiter, exempt []string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, path := range exempt {
+			if c.Path() == path || strings.HasPrefix(c.Path(), strings.TrimSuffix(path, "/")+"/") {
+				return c.Next()
+			}
+		}
+
+		result, err := limiter.Allow(c.UserContext(), "ip:"+c.IP())
+		if err != nil {
+			log.Printf("Warning: rate limit check skipped for %s: %v", c.IP(), err)
+			return c.Next()
+		}
+		if !result.Allowed {
+			retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+			return c.Status(429).JSON(fiber.Map{
+				"error": "Too many requests, try again later",
+			})
+		}
+		return c.Next()
+	}
+}
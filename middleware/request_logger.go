@@ -5,12 +5,33 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
-// RequestLogger logs request details
+// requestIDLocal is the c.Locals key holding the request id
+const requestIDLocal = "request_id"
+
+// HeaderRequestID carries the request id back to the client
+const HeaderRequestID = "X-Request-ID"
+
+// RequestID returns the id RequestLogger gave the request, or "-" outside it.
+// Handlers include it in their log lines so they can be matched to the
+// request line.
+func RequestID(c *fiber.Ctx) string {
+	if id, ok := c.Locals(requestIDLocal).(string); ok {
+		return id
+	}
+	return "-"
+}
+
+// RequestLogger gives each request a UUID, returned in X-Request-ID, and logs
+// the request details with it
 func RequestLogger() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
+		id := uuid.NewString()
+		c.Locals(requestIDLocal, id)
+		c.Set(HeaderRequestID, id)
 
 		// Process request
 		err := c.Next()
@@ -20,8 +41,9 @@ func RequestLogger() fiber.Handler {
 
 		// Log request details
 		log.Printf(
-			"[%s] %s %s - %d - %v - %s",
+			"[%s] [%s] %s %s - %d - %v - %s",
 			start.Format("2006-01-02 15:04:05"),
+			id,
 			c.Method(),
 			c.Path(),
 			c.Response().StatusCode(),
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"job-tracker/utils"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FieldType is the JSON type a body field must have
+type FieldType string
+
+const (
+	TypeString FieldType = "string"
+	TypeNumber FieldType = "number"
+	TypeBool   FieldType = "boolean"
+	TypeArray  FieldType = "array"
+	TypeObject FieldType = "object"
+)
+
+// withArticle returns the type name after "a" or "an", as in "an array"
+func (t FieldType) withArticle() string {
+	if strings.ContainsRune("aeiou", rune(t[0])) {
+		return "an " + string(t)
+	}
+	return "a " + string(t)
+}
+
+// Field describes a single field of a JSON request body
+type Field struct {
+	Type     FieldType
+	Required bool
+}
+
+// Schema maps JSON body field names to their rules. Fields that are not listed
+// are passed through untouched.
+type Schema map[string]Field
+
+// ValidateBody rejects requests whose JSON body is missing required fields or has
+// fields of the wrong type, reporting every problem at once with a 422
+func ValidateBody(schema Schema) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body map[string]interface{}
+		if err := json.Unmarshal(c.Body(), &body); err != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+
+		if errs := schema.Validate(body); len(errs) > 0 {
+			return c.Status(422).JSON(fiber.Map{"errors": errs})
+		}
+		return c.Next()
+	}
+}
+
+// Validate checks a decoded JSON object against the schema, returning every
+// problem in field order
+func (schema Schema) Validate(body map[string]interface{}) []utils.FieldError {
+	// Check fields in a stable order so the error list is deterministic
+	names := make([]string, 0, len(schema))
+	for name := range schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []utils.FieldError
+	for _, name := range names {
+		field := schema[name]
+		value, present := body[name]
+
+		if !present || value == nil {
+			if field.Required {
+				errs = append(errs, utils.FieldError{Field: name, Message: "is required"})
+			}
+			continue
+		}
+
+		if !hasType(value, field.Type) {
+			errs = append(errs, utils.FieldError{Field: name, Message: fmt.Sprintf("must be %s", field.Type.withArticle())})
+			continue
+		}
+
+		if str, ok := value.(string); ok && field.Required && strings.TrimSpace(str) == "" {
+			errs = append(errs, utils.FieldError{Field: name, Message: "must not be empty"})
+		}
+	}
+	return errs
+}
+
+// hasType reports whether a decoded JSON value matches the expected type
+func hasType(value interface{}, expected FieldType) bool {
+	switch expected {
+	case TypeString:
+		_, ok := value.(string)
+		return ok
+	case TypeNumber:
+		_, ok := value.(float64)
+		return ok
+	case TypeBool:
+		_, ok := value.(bool)
+		return ok
+	case TypeArray:
+		_, ok := value.([]interface{})
+		return ok
+	case TypeObject:
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"job-tracker/utils"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var testSchema = Schema{
+	"name":   {Type: TypeString, Required: true},
+	"age":    {Type: TypeNumber},
+	"active": {Type: TypeBool},
+	"tags":   {Type: TypeArray},
+	"meta":   {Type: TypeObject},
+}
+
+func TestSchemaValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []utils.FieldError
+	}{
+		{"valid", `{"name": "Ada", "age": 36, "active": true, "tags": ["a"], "meta": {}}`, nil},
+		{"optional fields left out", `{"name": "Ada"}`, nil},
+		{"optional field null", `{"name": "Ada", "age": null}`, nil},
+		{"missing required", `{}`, []utils.FieldError{{Field: "name", Message: "is required"}}},
+		{"required null", `{"name": null}`, []utils.FieldError{{Field: "name", Message: "is required"}}},
+		{"required blank", `{"name": "  "}`, []utils.FieldError{{Field: "name", Message: "must not be empty"}}},
+		{"wrong types", `{"name": 5, "age": "36", "active": "yes", "tags": "a", "meta": []}`, []utils.FieldError{
+			{Field: "active", Message: "must be a boolean"},
+			{Field: "age", Message: "must be a number"},
+			{Field: "meta", Message: "must be an object"},
+			{Field: "name", Message: "must be a string"},
+			{Field: "tags", Message: "must be an array"},
+		}},
+		{"unknown fields pass through", `{"name": "Ada", "nickname": 7, "extra": {}}`, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var body map[string]interface{}
+			if err := json.Unmarshal([]byte(tc.body), &body); err != nil {
+				t.Fatal(err)
+			}
+			if got := testSchema.Validate(body); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Validate(%s) = %+v, want %+v", tc.body, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		errors int
+	}{
+		{"valid body reaches the handler", `{"name": "Ada"}`, 200, 0},
+		{"invalid JSON", `{"name":`, 400, 0},
+		{"not an object", `["Ada"]`, 400, 0},
+		{"every error reported", `{"age": "old", "tags": {}}`, 422, 3},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/", ValidateBody(testSchema), func(c *fiber.Ctx) error {
+				return c.SendString("handled")
+			})
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req, -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tc.status {
+				t.Fatalf("status = %d, want %d, body %s", resp.StatusCode, tc.status, body)
+			}
+			if tc.status == 422 {
+				var result struct {
+					Errors []utils.FieldError `json:"errors"`
+				}
+				if err := json.Unmarshal(body, &result); err != nil {
+					t.Fatal(err)
+				}
+				if len(result.Errors) != tc.errors {
+					t.Errorf("errors = %+v, want %d", result.Errors, tc.errors)
+				}
+			}
+		})
+	}
+}
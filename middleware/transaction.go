@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"job-tracker/database"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Locals keys for the request transaction and the work deferred until it commits
+const (
+	txLocalsKey          = "tx"
+	afterCommitLocalsKey = "tx_after_commit"
+)
+
+// Transaction runs each mutating request in one database transaction, so a
+// handler that writes several rows gets atomicity without threading a tx
+// through its helpers. Handlers read the transaction with DB. It is committed
+// when the handler returns a 2xx response and rolled back on any other
+// status, on a returned error, or on a panic, which is then passed on to the
+// recover middleware. GET, HEAD and OPTIONS requests are not wrapped.
+func Transaction() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+
+		tx := database.DB.WithContext(c.UserContext()).Begin()
+		if tx.Error != nil {
+			log.Printf("Database error starting request transaction: %v", tx.Error)
+			return c.Status(500).JSON(fiber.Map{"error": "Database unavailable"})
+		}
+		c.Locals(txLocalsKey, tx)
+
+		// Runs while a panic unwinds too, so a crashing handler never leaves
+		// the transaction open
+		finished := false
+		defer func() {
+			if !finished {
+				tx.Rollback()
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if status := c.Response().StatusCode(); status < 200 || status >= 300 {
+			return nil
+		}
+
+		err := tx.Commit().Error
+		finished = true
+		if err != nil {
+			log.Printf("Database error committing request transaction: %v", err)
+			return c.Status(500).JSON(fiber.Map{"error": "Failed to save changes"})
+		}
+
+		hooks, _ := c.Locals(afterCommitLocalsKey).([]func())
+		for _, hook := range hooks {
+			hook()
+		}
+		return nil
+	}
+}
+
+// DB returns the request's transaction when it runs under Transaction, and
+// the shared connection otherwise
+func DB(c *fiber.Ctx) *gorm.DB {
+	if tx, ok := c.Locals(txLocalsKey).(*gorm.DB); ok {
+		return tx
+	}
+	return database.DB
+}
+
+// AfterCommit defers work that must only happen once the request's writes are
+// saved, such as clearing caches or publishing events. Without a request
+// transaction it runs right away.
+func AfterCommit(c *fiber.Ctx, hook func()) {
+	if _, ok := c.Locals(txLocalsKey).(*gorm.DB); !ok {
+		hook()
+		return
+	}
+	hooks, _ := c.Locals(afterCommitLocalsKey).([]func())
+	c.Locals(afterCommitLocalsKey, append(hooks, hook))
+}
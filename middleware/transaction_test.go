@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"job-tracker/database"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// txItem is the row the transaction tests write
+type txItem struct {
+	ID   uint
+	Name string
+}
+
+// useTransactionDB points database.DB at an empty in-memory SQLite database
+// with a tx_items table for the duration of the test
+func useTransactionDB(t *testing.T) {
+	t.Helper()
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.ReplaceAll(t.Name(), "/", "_"))
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("test database handle: %v", err)
+	}
+	// One connection keeps the in-memory database alive, and means a query
+	// outside the request transaction only runs once it has finished
+	sqlDB.SetMaxOpenConns(1)
+	if err := db.AutoMigrate(&txItem{}); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() {
+		database.DB = previous
+		sqlDB.Close()
+	})
+}
+
+// countItems counts the saved rows, failing instead of waiting if a request
+// transaction still holds the connection
+func countItems(t *testing.T) int64 {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	var count int64
+	if err := database.DB.WithContext(ctx).Model(&txItem{}).Count(&count).Error; err != nil {
+		t.Fatalf("count items: %v", err)
+	}
+	return count
+}
+
+// newTransactionApp routes every method on /items through Transaction to a
+// handler that writes a row, registers an after-commit hook and then calls
+// finish to produce the response
+func newTransactionApp(t *testing.T, hooks *[]int64, finish func(c *fiber.Ctx) error) *fiber.App {
+	app := fiber.New()
+	app.Use(recover.New())
+	app.All("/items", Transaction(), func(c *fiber.Ctx) error {
+		if err := DB(c).Create(&txItem{Name: c.Method()}).Error; err != nil {
+			return err
+		}
+		AfterCommit(c, func() { *hooks = append(*hooks, countItems(t)) })
+		return finish(c)
+	})
+	return app
+}
+
+func TestTransactionCommitsOn2xx(t *testing.T) {
+	for _, status := range []int{200, 201, 204} {
+		t.Run(fmt.Sprint(status), func(t *testing.T) {
+			useTransactionDB(t)
+			var hooks []int64
+			app := newTransactionApp(t, &hooks, func(c *fiber.Ctx) error { return c.SendStatus(status) })
+
+			resp, err := app.Test(httptest.NewRequest("POST", "/items", nil), -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, status)
+			}
+			if count := countItems(t); count != 1 {
+				t.Errorf("%d rows saved, want 1", count)
+			}
+			// The hook saw the committed row from outside the transaction
+			if len(hooks) != 1 || hooks[0] != 1 {
+				t.Errorf("after-commit hooks saw %v rows, want one hook after the commit", hooks)
+			}
+		})
+	}
+}
+
+func TestTransactionRollsBack(t *testing.T) {
+	tests := []struct {
+		name   string
+		finish func(c *fiber.Ctx) error
+		want   int
+	}{
+		{"400", func(c *fiber.Ctx) error { return c.Status(400).JSON(fiber.Map{"error": "bad"}) }, 400},
+		{"409", func(c *fiber.Ctx) error { return c.Status(409).JSON(fiber.Map{"error": "taken"}) }, 409},
+		{"422", func(c *fiber.Ctx) error { return c.Status(422).JSON(fiber.Map{"error": "invalid"}) }, 422},
+		{"500", func(c *fiber.Ctx) error { return c.Status(500).JSON(fiber.Map{"error": "failed"}) }, 500},
+		{"redirect", func(c *fiber.Ctx) error { return c.Redirect("/elsewhere") }, 302},
+		{"returned error", func(c *fiber.Ctx) error { return errors.New("write failed") }, 500},
+		{"returned fiber error", func(c *fiber.Ctx) error { return fiber.NewError(404, "gone") }, 404},
+		{"returned error after 2xx", func(c *fiber.Ctx) error {
+			c.Status(200)
+			return errors.New("write failed")
+		}, 500},
+		{"panic", func(c *fiber.Ctx) error { panic("handler crashed") }, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTransactionDB(t)
+			var hooks []int64
+			app := newTransactionApp(t, &hooks, tt.finish)
+
+			// A panic reaches the recover middleware, which answers 500
+			resp, err := app.Test(httptest.NewRequest("POST", "/items", nil), -1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if count := countItems(t); count != 0 {
+				t.Errorf("%d rows saved, want the write rolled back", count)
+			}
+			if len(hooks) != 0 {
+				t.Errorf("after-commit hooks ran %d times, want none", len(hooks))
+			}
+		})
+	}
+}
+
+func TestTransactionRunsHooksInOrder(t *testing.T) {
+	useTransactionDB(t)
+	var order []string
+	app := fiber.New()
+	app.Post("/items", Transaction(), func(c *fiber.Ctx) error {
+		for _, name := range []string{"cache", "event", "counter"} {
+			name := name
+			AfterCommit(c, func() { order = append(order, name) })
+		}
+		if len(order) != 0 {
+			t.Error("hooks ran before the handler returned")
+		}
+		return c.SendStatus(200)
+	})
+
+	if _, err := app.Test(httptest.NewRequest("POST", "/items", nil), -1); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(order) != "[cache event counter]" {
+		t.Errorf("hooks ran as %v, want registration order", order)
+	}
+}
+
+func TestTransactionSkipsReads(t *testing.T) {
+	useTransactionDB(t)
+	for _, method := range []string{"GET", "HEAD", "OPTIONS"} {
+		app := fiber.New()
+		ran := false
+		app.Add(method, "/items", Transaction(), func(c *fiber.Ctx) error {
+			if DB(c) != database.DB {
+				t.Errorf("%s: DB is a transaction, want the shared connection", method)
+			}
+			AfterCommit(c, func() { ran = true })
+			if !ran {
+				t.Errorf("%s: AfterCommit deferred the hook without a transaction", method)
+			}
+			return c.SendStatus(200)
+		})
+		if _, err := app.Test(httptest.NewRequest(method, "/items", nil), -1); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDBWithoutTransaction(t *testing.T) {
+	useTransactionDB(t)
+	app := fiber.New()
+	ran := false
+	app.Post("/items", func(c *fiber.Ctx) error {
+		if DB(c) != database.DB {
+			t.Error("DB is not the shared connection outside Transaction")
+		}
+		AfterCommit(c, func() { ran = true })
+		return c.SendStatus(201)
+	})
+	if _, err := app.Test(httptest.NewRequest("POST", "/items", nil), -1); err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Error("AfterCommit did not run the hook right away")
+	}
+}
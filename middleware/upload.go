@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"fmt"
+	"job-tracker/config"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UploadLimiter bounds the number of uploads handled at once and rejects
+// oversized ones before the handler parses them. Requests over the limit get
+// 503 with Retry-After instead of queueing, so a burst of large uploads can't
+// exhaust memory or disk. The size check compares the declared Content-Length
+// with a file of MaxBytes in its multipart framing; the handler checks the
+// exact file size. The body has already been read when this runs, so the app's
+// BodyLimit, which fasthttp enforces while reading, bounds the buffering.
+func UploadLimiter(cfg config.UploadConfig) fiber.Handler {
+	slots := make(chan struct{}, cfg.MaxConcurrent)
+	maxRequest := cfg.MaxRequestBytes()
+
+	return func(c *fiber.Ctx) error {
+		if size := c.Request().Header.ContentLength(); size > 0 && int64(size) > maxRequest {
+			return c.Status(413).JSON(fiber.Map{
+				"error": fmt.Sprintf("Upload exceeds the %d byte limit", cfg.MaxBytes),
+			})
+		}
+
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			return c.Next()
+		default:
+			log.Printf("Upload rejected: %d uploads already in progress", cfg.MaxConcurrent)
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(cfg.RetryAfter.Seconds())))
+			return c.Status(503).JSON(fiber.Map{
+				"error": "Too many uploads in progress, try again later",
+			})
+		}
+	}
+}
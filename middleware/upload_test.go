@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"bytes"
+	"job-tracker/config"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newUploadApp creates an app whose upload handler blocks until release is
+// closed, signalling entered each time it starts
+func newUploadApp(cfg config.UploadConfig, entered chan<- struct{}, release <-chan struct{}, calls *int32) *fiber.App {
+	app := fiber.New(fiber.Config{BodyLimit: 1 << 20})
+	app.Post("/upload", UploadLimiter(cfg), func(c *fiber.Ctx) error {
+		atomic.AddInt32(calls, 1)
+		entered <- struct{}{}
+		<-release
+		return c.SendStatus(201)
+	})
+	return app
+}
+
+func TestUploadLimiterRejectsOverLimit(t *testing.T) {
+	cfg := config.UploadConfig{MaxConcurrent: 2, MaxBytes: 1024, RetryAfter: 5 * time.Second}
+	entered := make(chan struct{}, 3)
+	release := make(chan struct{})
+	var calls int32
+	app := newUploadApp(cfg, entered, release, &calls)
+
+	// Fill every slot with an upload that stays in progress
+	var wg sync.WaitGroup
+	statuses := make([]int, cfg.MaxConcurrent)
+	for i := 0; i < cfg.MaxConcurrent; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := app.Test(httptest.NewRequest("POST", "/upload", bytes.NewReader([]byte("resume"))), -1)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			statuses[i] = resp.StatusCode
+		}(i)
+	}
+	for i := 0; i < cfg.MaxConcurrent; i++ {
+		select {
+		case <-entered:
+		case <-time.After(5 * time.Second):
+			t.Fatal("uploads did not start")
+		}
+	}
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/upload", bytes.NewReader([]byte("resume"))), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 503 {
+		t.Errorf("upload over the limit status = %d, want 503", resp.StatusCode)
+	}
+	if got := resp.Header.Get(fiber.HeaderRetryAfter); got != "5" {
+		t.Errorf("Retry-After = %q, want 5", got)
+	}
+
+	close(release)
+	wg.Wait()
+	for i, status := range statuses {
+		if status != 201 {
+			t.Errorf("upload %d status = %d, want 201", i, status)
+		}
+	}
+	if calls := atomic.LoadInt32(&calls); calls != int32(cfg.MaxConcurrent) {
+		t.Errorf("handler ran %d times, want %d", calls, cfg.MaxConcurrent)
+	}
+
+	// Finished uploads free their slots
+	resp, err = app.Test(httptest.NewRequest("POST", "/upload", bytes.NewReader([]byte("resume"))), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-entered
+	if resp.StatusCode != 201 {
+		t.Errorf("upload after the burst status = %d, want 201", resp.StatusCode)
+	}
+}
+
+func TestUploadLimiterRejectsOversizedBody(t *testing.T) {
+	cfg := config.UploadConfig{MaxConcurrent: 1, MaxBytes: 1024, RetryAfter: 5 * time.Second}
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	close(release)
+	var calls int32
+	app := newUploadApp(cfg, entered, release, &calls)
+
+	tests := []struct {
+		size int
+		want int
+	}{
+		{1024, 201},
+		{1024 + config.MultipartOverhead, 201},
+		{1024 + config.MultipartOverhead + 1, 413},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest("POST", "/upload", bytes.NewReader(make([]byte, tt.size))), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("%d byte upload status = %d, want %d", tt.size, resp.StatusCode, tt.want)
+		}
+		if tt.want == 201 {
+			<-entered
+		}
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 2 {
+		t.Errorf("handler ran %d times, want only for the uploads within the limit", calls)
+	}
+}
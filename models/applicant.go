@@ -1,6 +1,8 @@
 package models
 
 import (
+	"job-tracker/pii"
+	"job-tracker/utils"
 	"time"
 	"gorm.io/gorm"
 )
@@ -13,11 +15,32 @@ type Applicant struct {
 	
 	Name     string `json:"name" gorm:"not null;size:100"`
 	Email    string `json:"email" gorm:"unique;not null;size:150"`
+	// CanonicalEmail is Email as the mailbox provider sees it, used to spot duplicates
+	CanonicalEmail string `json:"-" gorm:"size:150;index"`
 	Position string `json:"position" gorm:"not null;size:100"`
-	Status   string `json:"status" gorm:"default:'pending';size:20"`
-	Phone    string `json:"phone,omitempty" gorm:"size:20"`
-	Resume   string `json:"resume,omitempty" gorm:"type:text"`
+	Status   utils.Status `json:"status" gorm:"default:'pending';size:20"`
+	// Phone and Resume are encrypted at rest when PII_ENCRYPTION_KEY_ID is set
+	Phone    pii.EncryptedString `json:"phone,omitempty" gorm:"size:255"`
+	PhoneExtension string `json:"phone_extension,omitempty" gorm:"size:10"`
+	Resume   pii.EncryptedString `json:"resume,omitempty" gorm:"type:text"`
 	Notes    string `json:"notes,omitempty" gorm:"type:text"`
+	// ResumeFile is the stored path of the uploaded resume PDF
+	ResumeFile string `json:"resume_file,omitempty" gorm:"size:255"`
+
+	LinkedInURL  string `json:"linkedin_url,omitempty" gorm:"size:500"`
+	PortfolioURL string `json:"portfolio_url,omitempty" gorm:"size:500"`
+
+	// ExpectedSalary is a whole amount in SalaryCurrency, an ISO 4217 code
+	ExpectedSalary *int64 `json:"expected_salary,omitempty" gorm:"index"`
+	SalaryCurrency string `json:"salary_currency,omitempty" gorm:"size:3"`
+
+	StatusChangedAt time.Time `json:"status_changed_at" gorm:"index"`
+
+	// AnonymizedAt is set once the applicant's personal data has been redacted
+	AnonymizedAt *time.Time `json:"anonymized_at,omitempty" gorm:"index"`
+
+	// Tags are only loaded when preloaded, and only changed through the tag endpoints
+	Tags TagList `json:"tags" gorm:"many2many:applicant_tags;constraint:OnDelete:CASCADE"`
 }
 
 // TableName returns the table name for the Applicant model
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLog records an action performed on an applicant
+type AuditLog struct {
+	ID          uint            `json:"id" gorm:"primarykey"`
+	CreatedAt   time.Time       `json:"created_at" gorm:"index"`
+	ApplicantID uint            `json:"applicant_id" gorm:"index;not null"`
+	Action      string          `json:"action" gorm:"not null;size:50"`
+	Actor       string          `json:"actor,omitempty" gorm:"size:100"`
+	Changes     json.RawMessage `json:"changes,omitempty" gorm:"type:jsonb"`
+	Reason      string          `json:"reason,omitempty" gorm:"type:text"`
+}
+
+// FieldChange describes the old and new value of a single field
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// TableName returns the table name for the AuditLog model
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// AuthEvent records an authentication attempt or credential change. It is kept
+// apart from the applicant audit log and is only readable by admins.
+type AuthEvent struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+	Type      string    `json:"type" gorm:"not null;size:50;index"`
+	Success   bool      `json:"success" gorm:"index"`
+	// Identity is the user or key the event is about. Rejected credentials are
+	// recorded by fingerprint, never in full.
+	Identity  string `json:"identity,omitempty" gorm:"size:100;index"`
+	IP        string `json:"ip" gorm:"size:45;index"`
+	UserAgent string `json:"user_agent,omitempty" gorm:"size:255"`
+	Detail    string `json:"detail,omitempty" gorm:"type:text"`
+}
+
+// TableName returns the table name for the AuthEvent model
+func (AuthEvent) TableName() string {
+	return "auth_events"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Comment is one message in an applicant's discussion thread. Unlike Notes,
+// each comment is its own row with an author, so several recruiters can
+// discuss a candidate without editing the same text.
+type Comment struct {
+	ID          uint      `json:"id" gorm:"primarykey"`
+	ApplicantID uint      `json:"applicant_id" gorm:"not null;index:idx_comments_applicant,priority:1"`
+	AuthorID    string    `json:"author_id" gorm:"not null;size:100"`
+	Body        string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"index:idx_comments_applicant,priority:2"`
+}
+
+// TableName returns the table name for the Comment model
+func (Comment) TableName() string {
+	return "comments"
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+)
+
+// diffIgnoredFields are fields left out of applicant diffs
+var diffIgnoredFields = map[string]bool{
+	"id":                true,
+	"created_at":        true,
+	"updated_at":        true,
+	"deleted_at":        true,
+	"status_changed_at": true,
+	// Tag changes are audited by the tag endpoints themselves
+	"tags": true,
+}
+
+// DiffApplicants compares two versions of an applicant field by field and
+// returns the changed fields keyed by their JSON name
+func DiffApplicants(before, after Applicant) map[string]FieldChange {
+	changes := make(map[string]FieldChange)
+
+	oldValue := reflect.ValueOf(before)
+	newValue := reflect.ValueOf(after)
+	fields := oldValue.Type()
+	for i := 0; i < fields.NumField(); i++ {
+		name := jsonFieldName(fields.Field(i))
+		if name == "" || diffIgnoredFields[name] {
+			continue
+		}
+
+		oldField := oldValue.Field(i).Interface()
+		newField := newValue.Field(i).Interface()
+		if !reflect.DeepEqual(oldField, newField) {
+			changes[name] = FieldChange{Old: oldField, New: newField}
+		}
+	}
+	return changes
+}
+
+// IsApplicantField checks if name is the JSON name of an applicant field
+func IsApplicantField(name string) bool {
+	fields := reflect.TypeOf(Applicant{})
+	for i := 0; i < fields.NumField(); i++ {
+		if name != "" && jsonFieldName(fields.Field(i)) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonFieldName returns the name a struct field is encoded under, or "" when
+// it is not encoded
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
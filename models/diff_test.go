@@ -0,0 +1,68 @@
+package models
+
+import (
+	"job-tracker/utils"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffApplicants(t *testing.T) {
+	salary, sameSalary, raise := int64(90000), int64(90000), int64(95000)
+	before := Applicant{
+		ID: 1, Name: "Ada Lovelace", Email: "ada@example.com", Position: "Engineer",
+		Status: utils.StatusPending, Notes: "First call", ExpectedSalary: &salary,
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name   string
+		change func(a *Applicant)
+		want   map[string]FieldChange
+	}{
+		{"nothing", func(a *Applicant) {}, map[string]FieldChange{}},
+		{"partial update", func(a *Applicant) {
+			a.Position = "Staff Engineer"
+			a.Notes = ""
+		}, map[string]FieldChange{
+			"position": {Old: "Engineer", New: "Staff Engineer"},
+			"notes":    {Old: "First call", New: ""},
+		}},
+		{"status", func(a *Applicant) { a.Status = utils.StatusReviewed }, map[string]FieldChange{
+			"status": {Old: utils.StatusPending, New: utils.StatusReviewed},
+		}},
+		{"equal salary at another address", func(a *Applicant) { a.ExpectedSalary = &sameSalary }, map[string]FieldChange{}},
+		{"salary", func(a *Applicant) { a.ExpectedSalary = &raise }, map[string]FieldChange{
+			"expected_salary": {Old: &salary, New: &raise},
+		}},
+		{"ignored and hidden fields", func(a *Applicant) {
+			a.ID = 2
+			a.CreatedAt = a.CreatedAt.Add(time.Hour)
+			a.UpdatedAt = time.Now()
+			a.StatusChangedAt = time.Now()
+			a.CanonicalEmail = "ada@example.com"
+			a.Tags = TagList{{Name: "referral"}}
+		}, map[string]FieldChange{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			after := before
+			tc.change(&after)
+			if got := DiffApplicants(before, after); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("DiffApplicants = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsApplicantField(t *testing.T) {
+	tests := map[string]bool{
+		"name": true, "linkedin_url": true, "tags": true, "deleted_at": true,
+		"canonical_email": false, "-": false, "": false, "nickname": false,
+	}
+	for name, want := range tests {
+		if got := IsApplicantField(name); got != want {
+			t.Errorf("IsApplicantField(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"job-tracker/utils"
+	"time"
+)
+
+// StatusHistory records one status change of an applicant. Unlike the audit
+// log it holds nothing but status moves, so a timeline is a plain query.
+type StatusHistory struct {
+	ID          uint         `json:"id" gorm:"primarykey"`
+	ApplicantID uint         `json:"applicant_id" gorm:"not null;index:idx_status_history_applicant,priority:1"`
+	FromStatus  utils.Status `json:"from_status" gorm:"size:20"`
+	ToStatus    utils.Status `json:"to_status" gorm:"not null;size:20"`
+	ChangedBy   string       `json:"changed_by,omitempty" gorm:"size:100"`
+	ChangedAt   time.Time    `json:"changed_at" gorm:"not null;index:idx_status_history_applicant,priority:2"`
+}
+
+// TableName returns the table name for the StatusHistory model
+func (StatusHistory) TableName() string {
+	return "status_history"
+}
@@ -0,0 +1,13 @@
+package models
+
+// ApplicantStatusCount is one row of the status summary table. The rows are
+// maintained by a database trigger on applicants, so they change in the same
+// transaction as the applicant rows they count.
+type ApplicantStatusCount struct {
+	Status string `json:"status" gorm:"primaryKey;size:50"`
+	Count  int64  `json:"count" gorm:"not null;default:0"`
+}
+
+func (ApplicantStatusCount) TableName() string {
+	return "applicant_status_counts"
+}
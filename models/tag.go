@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Tag is a label recruiters attach to applicants, such as "referral" or
+// "senior". Names are stored normalized: sanitized and lowercase.
+type Tag struct {
+	ID   uint   `json:"id" gorm:"primarykey"`
+	Name string `json:"name" gorm:"unique;not null;size:50"`
+}
+
+// TableName returns the table name for the Tag model
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TagList is the tags of an applicant. It is encoded as a sorted array of tag
+// names, and an applicant without tags gets an empty array rather than null.
+type TagList []Tag
+
+// Names returns the tag names in alphabetical order
+func (l TagList) Names() []string {
+	names := make([]string, len(l))
+	for i, tag := range l {
+		names[i] = tag.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MarshalJSON encodes the list as its tag names
+func (l TagList) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Names())
+}
+
+// UnmarshalJSON decodes a list of tag names, such as a cached applicant
+func (l *TagList) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*l = make(TagList, len(names))
+	for i, name := range names {
+		(*l)[i] = Tag{Name: name}
+	}
+	return nil
+}
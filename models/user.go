@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// User is an account that can log in to the API. Only the bcrypt hash of the
+// password is stored.
+type User struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Email        string    `json:"email" gorm:"unique;not null;size:150"`
+	PasswordHash string    `json:"-" gorm:"not null;size:60"`
+	Role         string    `json:"role" gorm:"not null;size:20"`
+}
+
+// TableName returns the table name for the User model
+func (User) TableName() string {
+	return "users"
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Webhook is an external URL notified of applicant events. Each delivery is
+// signed with Secret, which is only shown when the webhook is created.
+type Webhook struct {
+	ID        uint       `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time  `json:"created_at"`
+	URL       string     `json:"url" gorm:"not null;size:500"`
+	Events    EventTypes `json:"events" gorm:"type:text;not null;default:''"`
+	Secret    string     `json:"-" gorm:"not null;size:64"`
+}
+
+// TableName returns the table name for the Webhook model
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// EventTypes are the event types a webhook receives, stored comma-separated.
+// An empty list receives every type.
+type EventTypes []string
+
+// Includes reports whether eventType is one of the types, or the list is empty
+func (t EventTypes) Includes(eventType string) bool {
+	if len(t) == 0 {
+		return true
+	}
+	for _, wanted := range t {
+		if wanted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Value stores the types as a comma-separated list
+func (t EventTypes) Value() (driver.Value, error) {
+	return strings.Join(t, ","), nil
+}
+
+// Scan reads a comma-separated list of types
+func (t *EventTypes) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case nil:
+	default:
+		return fmt.Errorf("cannot scan %T into EventTypes", value)
+	}
+
+	*t = EventTypes{}
+	for _, eventType := range strings.Split(raw, ",") {
+		if eventType != "" {
+			*t = append(*t, eventType)
+		}
+	}
+	return nil
+}
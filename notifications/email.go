@@ -0,0 +1,158 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	"job-tracker/config"
+	"job-tracker/models"
+	"log"
+	"mime"
+	"net/smtp"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// defaultTemplate is used for statuses without a template of their own
+const defaultTemplate = "default"
+
+// EmailData is the data available to the email templates
+type EmailData struct {
+	Name           string
+	Email          string
+	Position       string
+	Status         string
+	PreviousStatus string
+}
+
+var (
+	templates      map[string]*template.Template // parsed templates by name
+	statusTemplate map[string]string             // status -> template name
+	smtpConfig     config.SMTPConfig
+)
+
+// LoadTemplates parses every *.tmpl file in the configured directory. Each file
+// is a template named after the file and must define a "subject" and a "body"
+// block. It fails if the default template or any template referenced by the
+// status mapping is missing, so a bad deployment is caught at boot.
+func LoadTemplates(cfg config.NotificationsConfig) error {
+	paths, err := filepath.Glob(filepath.Join(cfg.TemplatesDir, "*.tmpl"))
+	if err != nil {
+		return err
+	}
+
+	parsed := make(map[string]*template.Template, len(paths))
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".tmpl")
+		tmpl, err := template.ParseFiles(path)
+		if err != nil {
+			return fmt.Errorf("parse email template %s: %w", path, err)
+		}
+		for _, block := range []string{"subject", "body"} {
+			if tmpl.Lookup(block) == nil {
+				return fmt.Errorf("email template %s does not define %q", path, block)
+			}
+		}
+		parsed[name] = tmpl
+	}
+
+	if _, ok := parsed[defaultTemplate]; !ok {
+		return fmt.Errorf("email template %q not found in %s", defaultTemplate, cfg.TemplatesDir)
+	}
+	for status, name := range cfg.StatusTemplates {
+		if _, ok := parsed[name]; !ok {
+			return fmt.Errorf("email template %q for status %q not found in %s", name, status, cfg.TemplatesDir)
+		}
+	}
+
+	templates = parsed
+	statusTemplate = cfg.StatusTemplates
+	smtpConfig = cfg.SMTP
+	log.Printf("Loaded %d email templates from %s", len(parsed), cfg.TemplatesDir)
+	return nil
+}
+
+// Render produces the subject and body of the email for a status. The subject
+// is folded onto one line, so applicant fields such as the position can't add
+// header lines to the message.
+func Render(status string, data EmailData) (string, string, error) {
+	name, ok := statusTemplate[status]
+	if !ok {
+		name = defaultTemplate
+	}
+	tmpl := templates[name]
+	if tmpl == nil {
+		return "", "", fmt.Errorf("email templates not loaded")
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return "", "", err
+	}
+	return singleLine(subject.String()), strings.TrimSpace(body.String()) + "\n", nil
+}
+
+// StatusChanged emails the applicant about their new status. Without an SMTP
+// host configured the rendered email is only logged. It is run as a background
+// job, which retries it when rendering or sending fails.
+func StatusChanged(applicant models.Applicant, previousStatus string) error {
+	subject, body, err := Render(string(applicant.Status), EmailData{
+		Name:           applicant.Name,
+		Email:          applicant.Email,
+		Position:       applicant.Position,
+		Status:         string(applicant.Status),
+		PreviousStatus: previousStatus,
+	})
+	if err != nil {
+		return fmt.Errorf("render status email for applicant %d: %w", applicant.ID, err)
+	}
+
+	if smtpConfig.Host == "" {
+		log.Printf("Email to %s (not sent, SMTP not configured): %s", applicant.Email, subject)
+		return nil
+	}
+	if err := send(applicant.Email, subject, body); err != nil {
+		return fmt.Errorf("send status email to applicant %d: %w", applicant.ID, err)
+	}
+	return nil
+}
+
+// singleLine replaces every run of whitespace, including CR and LF, with a
+// single space
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// buildMessage assembles a plain-text email. Header values must not contain
+// line breaks; the subject is Q-encoded so non-ASCII text survives transport.
+func buildMessage(from, to, subject, body string) (string, error) {
+	for _, value := range []string{from, to, subject} {
+		if strings.ContainsAny(value, "\r\n") {
+			return "", fmt.Errorf("email header value %q contains a line break", value)
+		}
+	}
+	return "From: " + from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body, nil
+}
+
+// send delivers a plain-text email through the configured SMTP server
+func send(to, subject, body string) error {
+	msg, err := buildMessage(smtpConfig.From, to, subject, body)
+	if err != nil {
+		return err
+	}
+
+	addr := fmt.Sprintf("%s:%s", smtpConfig.Host, smtpConfig.Port)
+	var auth smtp.Auth
+	if smtpConfig.Username != "" {
+		auth = smtp.PlainAuth("", smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)
+	}
+	return smtp.SendMail(addr, auth, smtpConfig.From, []string{to}, []byte(msg))
+}
@@ -0,0 +1,107 @@
+package notifications
+
+import (
+	"job-tracker/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadTestTemplates(t *testing.T) {
+	t.Helper()
+	err := LoadTemplates(config.NotificationsConfig{
+		TemplatesDir:    filepath.Join("..", "templates", "email"),
+		StatusTemplates: map[string]string{"hired": "hired", "rejected": "rejected"},
+	})
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+}
+
+func TestRenderFoldsSubjectOntoOneLine(t *testing.T) {
+	loadTestTemplates(t)
+
+	for _, position := range []string{
+		"Engineer\r\nBcc: attacker@example.com",
+		"Engineer\nBcc: attacker@example.com",
+		"Engineer\rBcc: attacker@example.com",
+	} {
+		subject, _, err := Render("pending", EmailData{Name: "Ada", Position: position, Status: "pending"})
+		if err != nil {
+			t.Fatalf("Render(%q): %v", position, err)
+		}
+		if strings.ContainsAny(subject, "\r\n") {
+			t.Errorf("subject for position %q contains a line break: %q", position, subject)
+		}
+		if !strings.Contains(subject, "Engineer Bcc: attacker@example.com") {
+			t.Errorf("subject = %q, want the position folded onto one line", subject)
+		}
+	}
+}
+
+func TestRenderUsesStatusTemplate(t *testing.T) {
+	loadTestTemplates(t)
+
+	hired, _, err := Render("hired", EmailData{Name: "Ada", Position: "Engineer", Status: "hired"})
+	if err != nil {
+		t.Fatalf("Render(hired): %v", err)
+	}
+	fallback, _, err := Render("reviewed", EmailData{Name: "Ada", Position: "Engineer", Status: "reviewed"})
+	if err != nil {
+		t.Fatalf("Render(reviewed): %v", err)
+	}
+	if hired == fallback {
+		t.Errorf("hired and default subjects are both %q, want the hired template", hired)
+	}
+}
+
+func TestBuildMessageRejectsLineBreaks(t *testing.T) {
+	tests := []struct {
+		name        string
+		to, subject string
+	}{
+		{"subject CRLF", "ada@example.com", "Hi\r\nBcc: attacker@example.com"},
+		{"subject LF", "ada@example.com", "Hi\nBcc: attacker@example.com"},
+		{"recipient CRLF", "ada@example.com\r\nBcc: attacker@example.com", "Hi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildMessage("jobs@example.com", tt.to, tt.subject, "body\n"); err == nil {
+				t.Error("buildMessage accepted a header value with a line break")
+			}
+		})
+	}
+}
+
+func TestBuildMessageEncodesSubject(t *testing.T) {
+	msg, err := buildMessage("jobs@example.com", "ada@example.com", "Ingénieur logiciel", "body\n")
+	if err != nil {
+		t.Fatalf("buildMessage: %v", err)
+	}
+	header, body, ok := strings.Cut(msg, "\r\n\r\n")
+	if !ok {
+		t.Fatalf("message has no header/body separator: %q", msg)
+	}
+	if !strings.Contains(header, "Subject: =?utf-8?q?Ing=C3=A9nieur_logiciel?=\r\n") {
+		t.Errorf("header = %q, want a Q-encoded subject", header)
+	}
+	if body != "body\n" {
+		t.Errorf("body = %q, want %q", body, "body\n")
+	}
+}
+
+func TestLoadTemplatesFailsOnMissingTemplate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "default.tmpl"),
+		[]byte(`{{define "subject"}}s{{end}}{{define "body"}}b{{end}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := LoadTemplates(config.NotificationsConfig{
+		TemplatesDir:    dir,
+		StatusTemplates: map[string]string{"hired": "hired"},
+	})
+	if err == nil {
+		t.Fatal("LoadTemplates succeeded with a missing status template")
+	}
+}
@@ -0,0 +1,205 @@
+// Package pii encrypts personal data at rest. Fields of type EncryptedString
+// are encrypted when written to the database and decrypted when read, so the
+// rest of the code only ever sees plaintext.
+//
+// Encryption is deterministic: the nonce is derived from the plaintext, so the
+// same value always encrypts to the same ciphertext under a key. That gives
+// away which rows share a value, but keeps exact-match lookups working: pass
+// an EncryptedString as the query argument, e.g.
+// Where("phone = ?", pii.EncryptedString(phone)).
+//
+// Each configured key is split with HKDF into an AES-GCM key and a separate
+// HMAC key for the nonce. Values written before that (enc:v1:) used the
+// configured key for both; they are still read, and rewritten as enc:v2: by
+// the startup re-encryption.
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"job-tracker/config"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// Marker starts every encrypted value, whatever its version; anything
+	// without it is stored plaintext
+	Marker = "enc:"
+	// Prefix marks values encrypted with keys derived by HKDF, as new values are
+	Prefix = Marker + "v2:"
+	// prefixV1 marks values whose nonce was keyed with the encryption key itself
+	prefixV1 = Marker + "v1:"
+)
+
+// HKDF info strings separating the two keys derived from a configured key
+const (
+	encInfo   = "enc"
+	nonceInfo = "nonce"
+)
+
+// subkeys are the keys derived from one configured key
+type subkeys struct {
+	enc   []byte
+	nonce []byte
+}
+
+// Key material, set once at startup by Configure
+var (
+	activeKeyID string
+	keys        = map[string][]byte{}
+	derived     = map[string]subkeys{}
+)
+
+// Configure sets the encryption keys. New values are encrypted with the active
+// key; the others are only used to read values written before a rotation.
+// With no active key, values are written in plaintext.
+func Configure(cfg config.PIIConfig) {
+	activeKeyID = cfg.ActiveKeyID
+	keys = cfg.Keys
+	derived = make(map[string]subkeys, len(cfg.Keys))
+	for id, key := range cfg.Keys {
+		derived[id] = deriveSubkeys(key)
+	}
+}
+
+// deriveSubkeys splits key into an encryption key of the same length, so the
+// AES variant is kept, and a 32-byte nonce key
+func deriveSubkeys(key []byte) subkeys {
+	return subkeys{
+		enc:   hkdfKey(key, encInfo, len(key)),
+		nonce: hkdfKey(key, nonceInfo, sha256.Size),
+	}
+}
+
+func hkdfKey(key []byte, info string, size int) []byte {
+	out := make([]byte, size)
+	// Reading less than 255 hash lengths from HKDF can't fail
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, nil, []byte(info)), out); err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// Enabled reports whether new values are encrypted
+func Enabled() bool {
+	return activeKeyID != ""
+}
+
+// ActivePrefix is how values encrypted with the active key start. It is empty
+// when encryption is off.
+func ActivePrefix() string {
+	if !Enabled() {
+		return ""
+	}
+	return Prefix + activeKeyID + ":"
+}
+
+// Encrypt encrypts a value with the active key, returning it unchanged when
+// encryption is off or the value is empty
+func Encrypt(plaintext string) (string, error) {
+	if plaintext == "" || !Enabled() {
+		return plaintext, nil
+	}
+	sub := derived[activeKeyID]
+	gcm, err := newGCM(sub.enc)
+	if err != nil {
+		return "", err
+	}
+
+	// Synthetic nonce: an HMAC of the plaintext, so encryption is deterministic
+	mac := hmac.New(sha256.New, sub.nonce)
+	mac.Write([]byte(plaintext))
+	nonce := mac.Sum(nil)[:gcm.NonceSize()]
+
+	sealed := gcm.Seal(nonce, nonce, []byte(plaintext), []byte(activeKeyID))
+	return ActivePrefix() + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt reverses Encrypt with whichever key and version the value was
+// written with. Plaintext values, written before encryption was turned on,
+// are returned as is.
+func Decrypt(stored string) (string, error) {
+	var rest string
+	v1 := false
+	switch {
+	case strings.HasPrefix(stored, Prefix):
+		rest = strings.TrimPrefix(stored, Prefix)
+	case strings.HasPrefix(stored, prefixV1):
+		rest, v1 = strings.TrimPrefix(stored, prefixV1), true
+	default:
+		return stored, nil
+	}
+	keyID, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	key, ok := keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("value is encrypted with unknown key %q", keyID)
+	}
+	if !v1 {
+		key = derived[keyID].enc
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], []byte(keyID))
+	if err != nil {
+		return "", fmt.Errorf("decrypting with key %q: %w", keyID, err)
+	}
+	return string(plaintext), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptedString is a string column encrypted at rest. It behaves like a
+// string everywhere else, including in JSON.
+type EncryptedString string
+
+// Value encrypts the string on its way to the database
+func (s EncryptedString) Value() (driver.Value, error) {
+	return Encrypt(string(s))
+}
+
+// Scan decrypts a value read from the database
+func (s *EncryptedString) Scan(value interface{}) error {
+	var stored string
+	switch v := value.(type) {
+	case nil:
+		stored = ""
+	case string:
+		stored = v
+	case []byte:
+		stored = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EncryptedString", value)
+	}
+	plaintext, err := Decrypt(stored)
+	if err != nil {
+		return err
+	}
+	*s = EncryptedString(plaintext)
+	return nil
+}
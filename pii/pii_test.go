@@ -0,0 +1,198 @@
+package pii
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"job-tracker/config"
+	"strings"
+	"testing"
+)
+
+var (
+	key2023 = bytes.Repeat([]byte{0x23}, 32)
+	key2024 = bytes.Repeat([]byte{0x24}, 32)
+)
+
+// useKeys configures the given keys with active as the active key id for the
+// duration of the test
+func useKeys(t *testing.T, active string, ids ...string) {
+	t.Helper()
+	all := map[string][]byte{"2023": key2023, "2024": key2024}
+	keys := make(map[string][]byte, len(ids))
+	for _, id := range ids {
+		keys[id] = all[id]
+	}
+	Configure(config.PIIConfig{ActiveKeyID: active, Keys: keys})
+	t.Cleanup(func() { Configure(config.PIIConfig{}) })
+}
+
+func mustEncrypt(t *testing.T, plaintext string) string {
+	t.Helper()
+	stored, err := Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt(%q): %v", plaintext, err)
+	}
+	return stored
+}
+
+func TestRoundTrip(t *testing.T) {
+	useKeys(t, "2024", "2024")
+	for _, plaintext := range []string{"+15551234567", "résumé ✓", strings.Repeat("long resume ", 1000)} {
+		stored := mustEncrypt(t, plaintext)
+		if !strings.HasPrefix(stored, "enc:v2:2024:") || strings.Contains(stored, plaintext) {
+			t.Errorf("Encrypt(%.20q) = %.40q, want an enc:v2:2024: ciphertext", plaintext, stored)
+		}
+		got, err := Decrypt(stored)
+		if err != nil || got != plaintext {
+			t.Errorf("Decrypt(Encrypt(%.20q)) = %.20q, %v", plaintext, got, err)
+		}
+	}
+	if stored := mustEncrypt(t, ""); stored != "" {
+		t.Errorf("Encrypt(\"\") = %q, want empty", stored)
+	}
+}
+
+func TestEncryptionDisabled(t *testing.T) {
+	useKeys(t, "", "2024")
+	if Enabled() || ActivePrefix() != "" {
+		t.Error("encryption enabled without an active key")
+	}
+	if stored := mustEncrypt(t, "+15551234567"); stored != "+15551234567" {
+		t.Errorf("Encrypt = %q, want the plaintext", stored)
+	}
+	if got, err := Decrypt("+15551234567"); err != nil || got != "+15551234567" {
+		t.Errorf("Decrypt(plaintext) = %q, %v", got, err)
+	}
+}
+
+func TestEncryptIsDeterministic(t *testing.T) {
+	useKeys(t, "2024", "2023", "2024")
+	a, b := mustEncrypt(t, "+15551234567"), mustEncrypt(t, "+15551234567")
+	if a != b {
+		t.Errorf("equal plaintexts encrypted to %q and %q", a, b)
+	}
+	if c := mustEncrypt(t, "+15551234568"); c == a {
+		t.Error("different plaintexts share a ciphertext")
+	}
+
+	useKeys(t, "2023", "2023", "2024")
+	if c := mustEncrypt(t, "+15551234567"); strings.TrimPrefix(c, "enc:v2:2023:") == strings.TrimPrefix(a, "enc:v2:2024:") {
+		t.Error("different keys produced the same ciphertext")
+	}
+}
+
+func TestSubkeysAreSeparate(t *testing.T) {
+	sub := deriveSubkeys(key2024)
+	if len(sub.enc) != len(key2024) || len(sub.nonce) != sha256.Size {
+		t.Fatalf("subkey sizes = %d, %d", len(sub.enc), len(sub.nonce))
+	}
+	if bytes.Equal(sub.enc, sub.nonce[:len(sub.enc)]) || bytes.Equal(sub.enc, key2024) || bytes.Equal(sub.nonce, key2024) {
+		t.Error("derived keys repeat each other or the configured key")
+	}
+	// AES-128 keys stay AES-128
+	if short := deriveSubkeys(key2024[:16]); len(short.enc) != 16 {
+		t.Errorf("16-byte key derived a %d-byte encryption key", len(short.enc))
+	}
+}
+
+func TestDecryptAfterRotation(t *testing.T) {
+	useKeys(t, "2023", "2023")
+	old := mustEncrypt(t, "+15551234567")
+
+	useKeys(t, "2024", "2023", "2024")
+	if got, err := Decrypt(old); err != nil || got != "+15551234567" {
+		t.Errorf("Decrypt(old key value) = %q, %v", got, err)
+	}
+	if stored := mustEncrypt(t, "+15551234567"); !strings.HasPrefix(stored, ActivePrefix()) || ActivePrefix() != "enc:v2:2024:" {
+		t.Errorf("new value %q not written with the active key", stored)
+	}
+}
+
+func TestDecryptUnknownKey(t *testing.T) {
+	useKeys(t, "2023", "2023")
+	stored := mustEncrypt(t, "+15551234567")
+
+	useKeys(t, "2024", "2024")
+	if _, err := Decrypt(stored); err == nil || !strings.Contains(err.Error(), `unknown key "2023"`) {
+		t.Errorf("Decrypt error = %v, want unknown key 2023", err)
+	}
+}
+
+func TestDecryptTampered(t *testing.T) {
+	useKeys(t, "2024", "2023", "2024")
+	stored := mustEncrypt(t, "+15551234567")
+	sealed, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(stored, "enc:v2:2024:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	flip := func(i int) string {
+		tampered := append([]byte{}, sealed...)
+		tampered[i] ^= 1
+		return "enc:v2:2024:" + base64.RawStdEncoding.EncodeToString(tampered)
+	}
+
+	tests := map[string]string{
+		"nonce":          flip(0),
+		"ciphertext":     flip(len(sealed) / 2),
+		"tag":            flip(len(sealed) - 1),
+		"key id":         strings.Replace(stored, ":2024:", ":2023:", 1),
+		"version":        strings.Replace(stored, "enc:v2:", "enc:v1:", 1),
+		"truncated":      "enc:v2:2024:" + base64.RawStdEncoding.EncodeToString(sealed[:8]),
+		"not base64":     "enc:v2:2024:!!!",
+		"missing key id": "enc:v2:2024",
+	}
+	for name, value := range tests {
+		if got, err := Decrypt(value); err == nil {
+			t.Errorf("%s: Decrypt = %q, want an error", name, got)
+		}
+	}
+}
+
+func TestDecryptV1(t *testing.T) {
+	useKeys(t, "2024", "2024")
+
+	// A v1 value used the configured key for both AES-GCM and the nonce HMAC
+	gcm, err := newGCM(key2024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, key2024)
+	mac.Write([]byte("+15551234567"))
+	nonce := mac.Sum(nil)[:gcm.NonceSize()]
+	v1 := "enc:v1:2024:" + base64.RawStdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte("+15551234567"), []byte("2024")))
+
+	if got, err := Decrypt(v1); err != nil || got != "+15551234567" {
+		t.Errorf("Decrypt(v1) = %q, %v", got, err)
+	}
+	if v2 := mustEncrypt(t, "+15551234567"); v2 == v1 || !strings.HasPrefix(v2, Prefix) {
+		t.Errorf("new value %q, want it written as %s", v2, Prefix)
+	}
+}
+
+func TestEncryptedStringColumn(t *testing.T) {
+	useKeys(t, "2024", "2024")
+	value, err := EncryptedString("+15551234567").Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, ok := value.(string)
+	if !ok || !strings.HasPrefix(stored, ActivePrefix()) {
+		t.Fatalf("Value = %#v, want an encrypted string", value)
+	}
+
+	for _, raw := range []interface{}{stored, []byte(stored)} {
+		var s EncryptedString
+		if err := s.Scan(raw); err != nil || s != "+15551234567" {
+			t.Errorf("Scan(%T) = %q, %v", raw, s, err)
+		}
+	}
+	var s EncryptedString = "stale"
+	if err := s.Scan(nil); err != nil || s != "" {
+		t.Errorf("Scan(nil) = %q, %v", s, err)
+	}
+	if err := s.Scan(42); err == nil {
+		t.Error("Scan(int) succeeded")
+	}
+}
@@ -0,0 +1,142 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Limits holds the request quota per role for one window. A limit of 0 means
+// unlimited; roles without an entry use the "default" entry.
+type Limits map[string]int64
+
+// For returns the limit that applies to a role
+func (l Limits) For(role string) int64 {
+	if limit, ok := l[role]; ok {
+		return limit
+	}
+	return l["default"]
+}
+
+// Window is the usage of one quota window
+type Window struct {
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// Exceeded reports whether the window has no requests left
+func (w Window) Exceeded() bool {
+	return w.Limit > 0 && w.Used > w.Limit
+}
+
+// Usage is the quota state of a user across the daily and monthly windows
+type Usage struct {
+	Daily   Window `json:"daily"`
+	Monthly Window `json:"monthly"`
+}
+
+// Binding returns the limited window with the fewest requests left, which is
+// the one a client runs into first. It returns false when both are unlimited.
+func (u Usage) Binding() (Window, bool) {
+	var binding Window
+	found := false
+	for _, w := range []Window{u.Daily, u.Monthly} {
+		if w.Limit > 0 && (!found || w.Remaining < binding.Remaining) {
+			binding, found = w, true
+		}
+	}
+	return binding, found
+}
+
+// Tracker counts requests per user in Redis, in calendar-day and
+// calendar-month windows (UTC)
+type Tracker struct {
+	rdb     *redis.Client
+	daily   Limits
+	monthly Limits
+	now     func() time.Time // the clock that picks the window, replaced in tests
+}
+
+// NewTracker creates a tracker using the given Redis client and limits
+func NewTracker(rdb *redis.Client, daily, monthly Limits) *Tracker {
+	return &Tracker{rdb: rdb, daily: daily, monthly: monthly, now: time.Now}
+}
+
+// windows returns the Redis keys and reset times of the current windows
+func windows(userID string, now time.Time) (dayKey string, dayReset time.Time, monthKey string, monthReset time.Time) {
+	now = now.UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	dayKey = fmt.Sprintf("quota:%s:day:%s", userID, dayStart.Format("2006-01-02"))
+	monthKey = fmt.Sprintf("quota:%s:month:%s", userID, monthStart.Format("2006-01"))
+	return dayKey, dayStart.AddDate(0, 0, 1), monthKey, monthStart.AddDate(0, 1, 0)
+}
+
+// Consume counts one request for the user and returns the resulting usage.
+// A request that would exceed a window is not counted against it.
+func (t *Tracker) Consume(ctx context.Context, userID, role string) (Usage, error) {
+	dayKey, dayReset, monthKey, monthReset := windows(userID, t.now())
+
+	pipe := t.rdb.TxPipeline()
+	day := pipe.Incr(ctx, dayKey)
+	pipe.ExpireAt(ctx, dayKey, dayReset.Add(time.Hour))
+	month := pipe.Incr(ctx, monthKey)
+	pipe.ExpireAt(ctx, monthKey, monthReset.Add(time.Hour))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return Usage{}, err
+	}
+
+	usage := Usage{
+		Daily:   window(t.daily.For(role), day.Val(), dayReset),
+		Monthly: window(t.monthly.For(role), month.Val(), monthReset),
+	}
+
+	// Give the request back so rejected attempts don't eat into the quota
+	if usage.Daily.Exceeded() || usage.Monthly.Exceeded() {
+		t.rdb.Decr(ctx, dayKey)
+		t.rdb.Decr(ctx, monthKey)
+	}
+	return usage, nil
+}
+
+// Usage returns the current usage of the user without counting a request
+func (t *Tracker) Usage(ctx context.Context, userID, role string) (Usage, error) {
+	dayKey, dayReset, monthKey, monthReset := windows(userID, t.now())
+
+	values, err := t.rdb.MGet(ctx, dayKey, monthKey).Result()
+	if err != nil {
+		return Usage{}, err
+	}
+
+	return Usage{
+		Daily:   window(t.daily.For(role), toInt(values[0]), dayReset),
+		Monthly: window(t.monthly.For(role), toInt(values[1]), monthReset),
+	}, nil
+}
+
+func window(limit, used int64, reset time.Time) Window {
+	remaining := int64(-1) // unlimited
+	if limit > 0 {
+		remaining = limit - used
+		if remaining < 0 {
+			remaining = 0
+		}
+	}
+	return Window{Limit: limit, Used: used, Remaining: remaining, ResetAt: reset}
+}
+
+// toInt converts an MGET value, which is nil for missing keys
+func toInt(value interface{}) int64 {
+	s, ok := value.(string)
+	if !ok {
+		return 0
+	}
+	var n int64
+	fmt.Sscan(s, &n)
+	return n
+}
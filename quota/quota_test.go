@@ -0,0 +1,155 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// newTestTracker creates a tracker on an in-memory Redis whose clock reads *now
+func newTestTracker(t *testing.T, daily, monthly Limits, now *time.Time) (*Tracker, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	tracker := NewTracker(client, daily, monthly)
+	tracker.now = func() time.Time {
+		// EXPIREAT is compared with the server's clock, so keep it in step
+		server.SetTime(*now)
+		return *now
+	}
+	return tracker, server
+}
+
+func TestConsumeExhaustsQuota(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 14, 15, 0, 0, 0, time.UTC)
+	tracker, _ := newTestTracker(t, Limits{"default": 3}, Limits{"default": 100}, &now)
+
+	for i := int64(1); i <= 3; i++ {
+		usage, err := tracker.Consume(ctx, "ada", "recruiter")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if usage.Daily.Used != i || usage.Daily.Remaining != 3-i || usage.Daily.Exceeded() {
+			t.Fatalf("request %d: daily = %+v", i, usage.Daily)
+		}
+	}
+
+	usage, err := tracker.Consume(ctx, "ada", "recruiter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !usage.Daily.Exceeded() || usage.Daily.Remaining != 0 {
+		t.Fatalf("fourth request: daily = %+v, want exceeded", usage.Daily)
+	}
+	if want := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC); !usage.Daily.ResetAt.Equal(want) {
+		t.Errorf("daily reset = %v, want %v", usage.Daily.ResetAt, want)
+	}
+
+	// The rejected request is given back, in both windows
+	current, err := tracker.Usage(ctx, "ada", "recruiter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Daily.Used != 3 || current.Monthly.Used != 3 {
+		t.Errorf("usage after rejection = daily %d, monthly %d, want 3 and 3", current.Daily.Used, current.Monthly.Used)
+	}
+
+	// Other users have their own quota
+	if other, _ := tracker.Consume(ctx, "grace", "recruiter"); other.Daily.Used != 1 {
+		t.Errorf("another user's daily usage = %d, want 1", other.Daily.Used)
+	}
+}
+
+func TestQuotaWindowsReset(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)
+	tracker, server := newTestTracker(t, Limits{"default": 2}, Limits{"default": 3}, &now)
+
+	tracker.Consume(ctx, "ada", "")
+	tracker.Consume(ctx, "ada", "")
+	if usage, _ := tracker.Consume(ctx, "ada", ""); !usage.Daily.Exceeded() {
+		t.Fatalf("daily = %+v, want exceeded", usage.Daily)
+	}
+	if ttl := server.TTL("quota:ada:day:2024-01-31"); ttl <= 0 {
+		t.Errorf("daily key has no expiry")
+	}
+
+	// A new day, which is also a new month, starts both windows again
+	now = time.Date(2024, 2, 1, 0, 1, 0, 0, time.UTC)
+	usage, err := tracker.Consume(ctx, "ada", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Daily.Used != 1 || usage.Monthly.Used != 1 {
+		t.Errorf("after midnight: daily %d, monthly %d, want 1 and 1", usage.Daily.Used, usage.Monthly.Used)
+	}
+	if want := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC); !usage.Monthly.ResetAt.Equal(want) {
+		t.Errorf("monthly reset = %v, want %v", usage.Monthly.ResetAt, want)
+	}
+
+	// The next day resets only the daily window, so the monthly one runs out
+	now = now.AddDate(0, 0, 1)
+	tracker.Consume(ctx, "ada", "")
+	tracker.Consume(ctx, "ada", "")
+	now = now.AddDate(0, 0, 1)
+	usage, _ = tracker.Consume(ctx, "ada", "")
+	if usage.Daily.Exceeded() || !usage.Monthly.Exceeded() {
+		t.Errorf("third day: daily %+v, monthly %+v, want only the monthly window exceeded", usage.Daily, usage.Monthly)
+	}
+}
+
+func TestLimitsPerRole(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 14, 15, 0, 0, 0, time.UTC)
+	tracker, _ := newTestTracker(t, Limits{"default": 1, "service": 5, "admin": 0}, Limits{}, &now)
+
+	tests := []struct {
+		user, role string
+		requests   int
+		exceeded   bool
+	}{
+		{"ada", "recruiter", 2, true},
+		{"sync-bot", "service", 5, false},
+		{"sync-bot-2", "service", 6, true},
+		{"root", "admin", 50, false},
+	}
+	for _, tc := range tests {
+		var usage Usage
+		for i := 0; i < tc.requests; i++ {
+			var err error
+			if usage, err = tracker.Consume(ctx, tc.user, tc.role); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if usage.Daily.Exceeded() != tc.exceeded {
+			t.Errorf("%s (%s) after %d requests: daily = %+v, exceeded want %v", tc.user, tc.role, tc.requests, usage.Daily, tc.exceeded)
+		}
+	}
+
+	usage, _ := tracker.Usage(ctx, "root", "admin")
+	if usage.Daily.Remaining != -1 {
+		t.Errorf("unlimited remaining = %d, want -1", usage.Daily.Remaining)
+	}
+	if _, ok := usage.Binding(); ok {
+		t.Errorf("Binding found a window for an unlimited role")
+	}
+}
+
+func TestBinding(t *testing.T) {
+	usage := Usage{
+		Daily:   Window{Limit: 100, Remaining: 40},
+		Monthly: Window{Limit: 1000, Remaining: 10},
+	}
+	if window, ok := usage.Binding(); !ok || window.Limit != 1000 {
+		t.Errorf("Binding = %+v, %v, want the monthly window", window, ok)
+	}
+	usage.Monthly = Window{Limit: 0, Remaining: -1}
+	if window, ok := usage.Binding(); !ok || window.Limit != 100 {
+		t.Errorf("Binding = %+v, %v, want the daily window when monthly is unlimited", window, ok)
+	}
+}
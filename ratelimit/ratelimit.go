@@ -0,0 +1,79 @@
+// Package ratelimit limits how often a client may call the API, using a
+// sliding window kept in Redis so the limit holds across every instance.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// slidingWindow records a request in a sorted set of request times unless the
+// window is already full. Old entries are trimmed first, so the set only holds
+// the requests of the last window. It returns whether the request is allowed,
+// the number of requests in the window and, when refused, the milliseconds
+// until the oldest one leaves it.
+var slidingWindow = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call("ZREMRANGEBYSCORE", key, "-inf", now - window)
+local count = redis.call("ZCARD", key)
+if count < limit then
+	redis.call("ZADD", key, now, ARGV[4])
+	redis.call("PEXPIRE", key, window)
+	return {1, count + 1, 0}
+end
+local oldest = redis.call("ZRANGE", key, 0, 0, "WITHSCORES")
+return {0, count, tonumber(oldest[2]) + window - now}
+`)
+
+// Result is the outcome of one rate limit check
+type Result struct {
+	Allowed bool
+	Limit   int
+	// Remaining is the number of requests still allowed in the window
+	Remaining int
+	// RetryAfter is how long a refused client should wait
+	RetryAfter time.Duration
+}
+
+// Limiter allows a number of requests per key in a sliding window
+type Limiter struct {
+	rdb    *redis.Client
+	limit  int
+	window time.Duration
+}
+
+// NewLimiter creates a limiter allowing limit requests per window
+func NewLimiter(rdb *redis.Client, limit int, window time.Duration) *Limiter {
+	return &Limiter{rdb: rdb, limit: limit, window: window}
+}
+
+// Allow counts a request for the key if the window has room for it
+func (l *Limiter) Allow(ctx context.Context, key string) (Result, error) {
+	now := time.Now().UnixMilli()
+	// Requests in the same millisecond need distinct members
+	member := fmt.Sprintf("%d-%d", now, rand.Int63())
+
+	values, err := slidingWindow.Run(ctx, l.rdb, []string{"ratelimit:" + key},
+		now, l.window.Milliseconds(), l.limit, member).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{
+		Allowed:   values[0] == 1,
+		Limit:     l.limit,
+		Remaining: l.limit - int(values[1]),
+	}
+	if !result.Allowed {
+		result.RetryAfter = time.Duration(values[2]) * time.Millisecond
+	}
+	return result, nil
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"job-tracker/controllers"
+	"job-tracker/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupAdmin registers the operational endpoints, restricted to admins
+func setupAdmin(app *fiber.App, authenticated []fiber.Handler) {
+	admin := app.Group("/admin", chain(authenticated, middleware.RequireRole("admin"))...)
+	admin.Use(middleware.RequestLogger())
+
+	admin.Get("/cache/stats", controllers.GetCacheStats)
+	admin.Post("/cache/flush", controllers.FlushApplicantCache)
+	admin.Post("/cache/reconcile", controllers.ReconcileApplicantCache)
+	admin.Post("/reindex", controllers.ReindexApplicants)
+	admin.Get("/auth-events", controllers.ListAuthEvents)
+	admin.Get("/audit/export", controllers.ExportAuditLog)
+	admin.Get("/webhooks", controllers.ListWebhooks)
+	admin.Post("/webhooks", middleware.ValidateBody(controllers.WebhookSchema), controllers.CreateWebhook)
+	admin.Delete("/webhooks/:id", controllers.DeleteWebhook)
+}
@@ -1,26 +1,147 @@
 package routes
 
 import (
+	"context"
+	"job-tracker/authaudit"
+	"job-tracker/config"
 	"job-tracker/controllers"
+	"job-tracker/events"
+	"job-tracker/jobs"
 	"job-tracker/middleware"
+	"job-tracker/quota"
+	"job-tracker/ratelimit"
+	"job-tracker/webhooks"
+	"log"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func Setup(app *fiber.App) {
+func Setup(app *fiber.App, cfg *config.Config, queue jobs.Queue) {
 	// Initialize Redis connection
-	controllers.InitRedis()
+	controllers.InitRedis(cfg.Redis)
+	controllers.SetCacheConfig(cfg.Cache)
+	controllers.SetJobQueue(queue)
+	controllers.SetEventDispatcher(newEventDispatcher(cfg, queue))
+	controllers.SetUploadConfig(cfg.Upload)
+	authaudit.SetQueue(queue)
+	if err := controllers.SetApplicantConfig(cfg.Applicants); err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+	controllers.BackfillCanonicalEmails()
+	controllers.SeedAdminUser(cfg.Auth)
+	controllers.ReencryptPII()
+	controllers.ReconcileCreatedCounter()
+	controllers.ReconcileCacheOnStart()
+
+	// Every route registered from here on is limited per client IP
+	if cfg.RateLimit.Requests > 0 {
+		limiter := ratelimit.NewLimiter(controllers.RedisClient(), cfg.RateLimit.Requests, cfg.RateLimit.Window)
+		app.Use(middleware.RateLimit(limiter, cfg.RateLimit.ExemptPaths))
+	}
+
+	// Authenticated requests count against the caller's quota
+	quotas := quota.NewTracker(controllers.RedisClient(), cfg.Quota.Daily, cfg.Quota.Monthly)
+	authenticated := []fiber.Handler{middleware.JWTAuth(), middleware.Quota(quotas, cfg.Quota.WarnBelow)}
 	
 	// Setup applicant routes with middleware
 	api := app.Group("/applicants")
 	
 	// Add request logging middleware
 	api.Use(middleware.RequestLogger())
+
+	// Every applicant route needs a token. Reads and edits are open to any
+	// role; deletes and the other destructive operations need admin.
+	for _, handler := range authenticated {
+		api.Use(handler)
+	}
 	
-	// CRUD operations for applicants
-	api.Post("/", controllers.CreateApplicant)
+	// CRUD operations for applicants. "/" is served for both /applicants and
+	// /applicants/ because the app is configured without strict routing.
+	api.Post("/", middleware.ValidateBody(controllers.ApplicantCreateSchema), controllers.CreateApplicant)
+	api.Post("/bulk", middleware.Transaction(), controllers.BulkCreateApplicants)
+	// HEAD is registered first so it counts instead of running the GET handler
+	api.Head("/", controllers.HeadApplicants)
 	api.Get("/", controllers.GetApplicants)
+	api.Get("/overdue", controllers.GetOverdueApplicants)
+	api.Get("/board", controllers.GetApplicantBoard)
+	api.Get("/export", controllers.ExportApplicants)
+	api.Get("/metrics", controllers.GetApplicantMetrics)
+	api.Get("/stats", controllers.GetApplicantStats)
+	api.Get("/schema", controllers.GetApplicantSchema)
+	api.Get("/next", controllers.NextApplicant)
+	api.Get("/positions/suggest", controllers.SuggestPositions)
+	api.Post("/resumes/zip", middleware.ValidateBody(controllers.BatchIDsSchema), controllers.ZipResumes)
 	api.Get("/:id", controllers.GetApplicant)
-	api.Put("/:id", controllers.UpdateApplicant)
-	api.Delete("/:id", controllers.DeleteApplicant)
+	api.Get("/:id/history", controllers.GetApplicantStatusHistory)
+	api.Get("/:id/history/:field", controllers.GetApplicantFieldHistory)
+	api.Get("/:id/audit/export", middleware.RequireRole("admin"), controllers.ExportApplicantAudit)
+	api.Post("/mark-reviewed", middleware.ValidateBody(controllers.BatchIDsSchema), controllers.MarkApplicantsReviewed)
+	api.Post("/batch", middleware.ValidateBody(controllers.BatchIDsSchema), controllers.FetchApplicantsBatch)
+	api.Delete("/batch",
+		middleware.RequireRole("admin"),
+		middleware.ValidateBody(controllers.BatchIDsSchema),
+		middleware.Transaction(),
+		controllers.DeleteApplicantsBatch,
+	)
+	api.Put("/:id", middleware.ValidateBody(controllers.ApplicantUpdateSchema), controllers.UpdateApplicant)
+	api.Patch("/:id", middleware.ValidateBody(controllers.ApplicantUpdateSchema), controllers.PatchApplicant)
+	api.Delete("/:id", middleware.RequireRole("admin"), middleware.Transaction(), controllers.DeleteApplicant)
+	api.Post("/:id/transition", middleware.ValidateBody(controllers.TransitionSchema), controllers.TransitionApplicant)
+	api.Post("/:id/clone", middleware.ValidateBody(controllers.CloneSchema), controllers.CloneApplicant)
+	api.Post("/:id/resume", middleware.UploadLimiter(cfg.Upload), controllers.UploadResume)
+	api.Get("/:id/resume", controllers.DownloadResume)
+	api.Post("/:id/notes/append", middleware.ValidateBody(controllers.AppendNoteSchema), controllers.AppendApplicantNote)
+	api.Get("/:id/comments", controllers.GetComments)
+	api.Post("/:id/comments", middleware.ValidateBody(controllers.CommentSchema), controllers.CreateComment)
+	// Authors may delete their own comments; the handler lets admins delete any
+	api.Delete("/:id/comments/:commentId", controllers.DeleteComment)
+	api.Post("/:id/tags", middleware.ValidateBody(controllers.TagsSchema), controllers.AddApplicantTags)
+	api.Delete("/:id/tags/:tag", controllers.RemoveApplicantTag)
+	api.Post("/:id/anonymize", middleware.RequireRole("admin"), controllers.AnonymizeApplicant)
+	api.Post("/:id/reopen",
+		middleware.RequireRole(controllers.ReopenRoles()...),
+		middleware.ValidateBody(controllers.ReopenSchema),
+		controllers.ReopenApplicant,
+	)
+
+	// Login issues the tokens the authenticated routes check
+	app.Post("/auth/login", middleware.ValidateBody(controllers.LoginSchema), controllers.Login)
+
+	// Current user
+	me := app.Group("/me", authenticated...)
+	me.Get("/quota", controllers.GetMyQuota(quotas))
+
+	// Operational endpoints
+	setupAdmin(app, authenticated)
+}
+
+// newEventDispatcher creates the applicant event dispatcher, subscribing the
+// webhooks to every type and the event log to the types listed in
+// EVENT_LOG_TYPES
+func newEventDispatcher(cfg *config.Config, queue jobs.Queue) *events.Dispatcher {
+	dispatcher := events.NewDispatcher(queue)
+	// Each webhook filters the types it receives itself, so it can change at runtime
+	sender := webhooks.NewSender(queue, cfg.Events.WebhookTimeout)
+	if err := dispatcher.Subscribe("webhooks", nil, sender.Handle); err != nil {
+		log.Fatal("Failed to subscribe webhooks: ", err)
+	}
+	if len(cfg.Events.LogTypes) > 0 {
+		types := cfg.Events.LogTypes
+		if types[0] == "*" {
+			types = nil
+		}
+		err := dispatcher.Subscribe("log", types, func(ctx context.Context, event events.Event) error {
+			log.Printf("Event %s: applicant %d by %q, changes %v", event.Type, event.ApplicantID, event.Actor, event.Changes)
+			return nil
+		})
+		if err != nil {
+			log.Fatal("Invalid EVENT_LOG_TYPES: ", err)
+		}
+	}
+	return dispatcher
+}
+
+// chain appends handlers to a shared middleware list without modifying it
+func chain(middlewares []fiber.Handler, handlers ...fiber.Handler) []fiber.Handler {
+	return append(append([]fiber.Handler{}, middlewares...), handlers...)
 }
@@ -0,0 +1,22 @@
+package utils
+
+import "strings"
+
+// CanonicalEmail returns the address a mailbox provider actually delivers to,
+// for duplicate detection. For domains in providers, such as gmail.com, dots
+// and any "+tag" are removed from the local part, so u.s.e.r+jobs@gmail.com
+// becomes user@gmail.com. Other addresses are only lowercased.
+func CanonicalEmail(email string, providers map[string]bool) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at < 0 || !providers[email[at+1:]] {
+		return email
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if plus := strings.Index(local, "+"); plus >= 0 {
+		local = local[:plus]
+	}
+	local = strings.ReplaceAll(local, ".", "")
+	return local + "@" + domain
+}
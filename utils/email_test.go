@@ -0,0 +1,28 @@
+package utils
+
+import "testing"
+
+func TestCanonicalEmail(t *testing.T) {
+	gmail := map[string]bool{"gmail.com": true}
+	tests := []struct {
+		email     string
+		providers map[string]bool
+		want      string
+	}{
+		{"user@gmail.com", gmail, "user@gmail.com"},
+		{"u.s.e.r@gmail.com", gmail, "user@gmail.com"},
+		{"user+jobs@gmail.com", gmail, "user@gmail.com"},
+		{"U.Ser+jobs+more@Gmail.com", gmail, "user@gmail.com"},
+		{" user.name@gmail.com ", gmail, "username@gmail.com"},
+		{"u.s.e.r+jobs@googlemail.com", gmail, "u.s.e.r+jobs@googlemail.com"},
+		{"first.last+tag@example.com", gmail, "first.last+tag@example.com"},
+		{"u.s.e.r+jobs@gmail.com", nil, "u.s.e.r+jobs@gmail.com"},
+		{"User@Example.com", nil, "user@example.com"},
+		{"not-an-email", gmail, "not-an-email"},
+	}
+	for _, tc := range tests {
+		if got := CanonicalEmail(tc.email, tc.providers); got != tc.want {
+			t.Errorf("CanonicalEmail(%q, %v) = %q, want %q", tc.email, tc.providers, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// countryCallingCodes are the assigned ITU-T E.164 country calling codes. The
+// codes are prefix-free, so at most one of them matches the start of a number.
+var countryCallingCodes = map[string]bool{}
+
+func init() {
+	codes := []string{
+		"1", "7",
+		"20", "27", "30", "31", "32", "33", "34", "36", "39", "40", "41", "43", "44", "45", "46", "47", "48", "49",
+		"51", "52", "53", "54", "55", "56", "57", "58", "60", "61", "62", "63", "64", "65", "66",
+		"81", "82", "84", "86", "90", "91", "92", "93", "94", "95", "98",
+		"211", "212", "213", "216", "218",
+		"220", "221", "222", "223", "224", "225", "226", "227", "228", "229",
+		"230", "231", "232", "233", "234", "235", "236", "237", "238", "239",
+		"240", "241", "242", "243", "244", "245", "246", "247", "248", "249",
+		"250", "251", "252", "253", "254", "255", "256", "257", "258",
+		"260", "261", "262", "263", "264", "265", "266", "267", "268", "269",
+		"290", "291", "297", "298", "299",
+		"350", "351", "352", "353", "354", "355", "356", "357", "358", "359",
+		"370", "371", "372", "373", "374", "375", "376", "377", "378", "379",
+		"380", "381", "382", "383", "385", "386", "387", "389",
+		"420", "421", "423",
+		"500", "501", "502", "503", "504", "505", "506", "507", "508", "509",
+		"590", "591", "592", "593", "594", "595", "596", "597", "598", "599",
+		"670", "672", "673", "674", "675", "676", "677", "678", "679",
+		"680", "681", "682", "683", "685", "686", "687", "688", "689",
+		"690", "691", "692",
+		"800", "808", "850", "852", "853", "855", "856", "870", "878",
+		"880", "881", "882", "883", "886", "888",
+		"960", "961", "962", "963", "964", "965", "966", "967", "968",
+		"970", "971", "972", "973", "974", "975", "976", "977", "979",
+		"992", "993", "994", "995", "996", "998",
+	}
+	for _, code := range codes {
+		countryCallingCodes[code] = true
+	}
+}
+
+// phoneSeparators are the formatting characters allowed between digits
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+
+var internationalDigitsRegex = regexp.MustCompile(`^\+\d{8,15}$`)
+
+// normalizedPhoneRegex is the form NormalizePhone produces: 8 to 15 digits,
+// with a "+" when the country calling code is known
+var normalizedPhoneRegex = regexp.MustCompile(`^\+?\d{8,15}$`)
+
+// ErrInvalidPhone is returned for a number NormalizePhone can't make sense of
+var ErrInvalidPhone = errors.New("invalid phone number")
+
+// NormalizePhone strips the formatting from a phone number and returns it in
+// E.164 form, e.g. "(555) 123-4567" with default country code "1" becomes
+// "+15551234567". A "00" international prefix is read as "+". A number
+// without a country code gets defaultCountryCode, dropping a leading trunk
+// "0"; with no default it is returned as bare digits.
+func NormalizePhone(phone, defaultCountryCode string) (string, error) {
+	digits := phoneSeparators.Replace(phone)
+	if strings.HasPrefix(digits, "00") {
+		digits = "+" + digits[2:]
+	}
+	if !strings.HasPrefix(digits, "+") && defaultCountryCode != "" {
+		digits = "+" + strings.TrimPrefix(defaultCountryCode, "+") + strings.TrimPrefix(digits, "0")
+	}
+	if !normalizedPhoneRegex.MatchString(digits) {
+		return "", ErrInvalidPhone
+	}
+	return digits, nil
+}
+
+// ValidateCountryCallingCode checks that code, with or without a leading "+",
+// is an assigned country calling code
+func ValidateCountryCallingCode(code string) bool {
+	return countryCallingCodes[strings.TrimPrefix(code, "+")]
+}
+
+// ValidateInternationalPhone checks that a phone number starts with "+" and a
+// valid country calling code, rejecting local-only numbers
+func ValidateInternationalPhone(phone string) bool {
+	digits := phoneSeparators.Replace(phone)
+	if !internationalDigitsRegex.MatchString(digits) {
+		return false
+	}
+	for length := 1; length <= 3; length++ {
+		if countryCallingCodes[digits[1:1+length]] {
+			return true
+		}
+	}
+	return false
+}
+
+// phoneExtensionRegex matches a number followed by an extension written as
+// "x890", "ext 890", "ext. 890", "extension 890" or "#890"
+var phoneExtensionRegex = regexp.MustCompile(`(?i)^(.*?\d)[\s,;]*(?:x|ext\.?|extension|#)\s*(\d+)$`)
+
+// SplitPhoneExtension separates a trailing extension from a phone number and
+// collapses the spacing in the remaining base number. ext is empty when the
+// number has no extension.
+func SplitPhoneExtension(phone string) (base, ext string) {
+	base = phone
+	if match := phoneExtensionRegex.FindStringSubmatch(phone); match != nil {
+		base, ext = match[1], match[2]
+	}
+	return strings.Join(strings.Fields(base), " "), ext
+}
+
+// ValidatePhoneExtension checks that an extension is 1 to 6 digits
+func ValidatePhoneExtension(ext string) bool {
+	return regexp.MustCompile(`^\d{1,6}$`).MatchString(ext)
+}
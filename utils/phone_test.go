@@ -0,0 +1,54 @@
+package utils
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone, country, want string
+		err                  bool
+	}{
+		{"+1 (555) 123-4567", "", "+15551234567", false},
+		{"0044 20 7946 0958", "", "+442079460958", false},
+		{"(555) 123-4567", "", "5551234567", false},
+		{"(555) 123-4567", "1", "+15551234567", false},
+		{"020 7946 0958", "44", "+442079460958", false},
+		{"+33 1 23 45 67 89", "1", "+33123456789", false},
+		{"123", "", "", true},
+		{"555-CALL-NOW", "", "", true},
+		{"+1234567890123456", "", "", true},
+	}
+	for _, tc := range tests {
+		got, err := NormalizePhone(tc.phone, tc.country)
+		if (err != nil) != tc.err || got != tc.want {
+			t.Errorf("NormalizePhone(%q, %q) = %q, %v, want %q, error %v", tc.phone, tc.country, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestValidateInternationalPhone(t *testing.T) {
+	tests := map[string]bool{
+		"+15551234567":      true,
+		"+44 20 7946 0958":  true,
+		"+250 788 123 456":  true,
+		"+7 912 345 67 89":  true,
+		"5551234567":        false,
+		"0044 20 7946 0958": false,
+		"+999 1234 5678":    false,
+		"+0 555 123 4567":   false,
+		"+1 555":            false,
+	}
+	for phone, want := range tests {
+		if got := ValidateInternationalPhone(phone); got != want {
+			t.Errorf("ValidateInternationalPhone(%q) = %v, want %v", phone, got, want)
+		}
+	}
+}
+
+func TestValidateCountryCallingCode(t *testing.T) {
+	tests := map[string]bool{"1": true, "+44": true, "250": true, "999": false, "0": false, "": false, "+": false}
+	for code, want := range tests {
+		if got := ValidateCountryCallingCode(code); got != want {
+			t.Errorf("ValidateCountryCallingCode(%q) = %v, want %v", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package utils
+
+// Status is an applicant's stage in the hiring pipeline
+type Status string
+
+// The applicant statuses, in pipeline order
+const (
+	StatusPending     Status = "pending"
+	StatusReviewed    Status = "reviewed"
+	StatusInterviewed Status = "interviewed"
+	StatusHired       Status = "hired"
+	StatusRejected    Status = "rejected"
+)
+
+// allowedStatuses lists every applicant status in pipeline order
+var allowedStatuses = []Status{StatusPending, StatusReviewed, StatusInterviewed, StatusHired, StatusRejected}
+
+// statusTransitions lists the statuses each status may move to. Hired and
+// rejected are terminal.
+var statusTransitions = map[Status][]Status{
+	StatusPending:     {StatusReviewed, StatusRejected},
+	StatusReviewed:    {StatusInterviewed, StatusRejected},
+	StatusInterviewed: {StatusHired, StatusRejected},
+}
+
+// Valid checks if s is one of the allowed statuses
+func (s Status) Valid() bool {
+	for _, allowed := range allowedStatuses {
+		if s == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionTo checks if an applicant may move from s to another status
+func (s Status) CanTransitionTo(to Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if to == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal checks if s is a valid status with no outgoing transitions
+func (s Status) IsTerminal() bool {
+	return s.Valid() && len(statusTransitions[s]) == 0
+}
+
+// AllowedStatuses returns every applicant status in pipeline order
+func AllowedStatuses() []string {
+	statuses := make([]string, len(allowedStatuses))
+	for i, status := range allowedStatuses {
+		statuses[i] = string(status)
+	}
+	return statuses
+}
+
+// ValidateStatus checks if status is one of the allowed values
+func ValidateStatus(status string) bool {
+	return Status(status).Valid()
+}
+
+// ValidateTransition checks if an applicant may move from one status to another
+func ValidateTransition(from, to string) bool {
+	return Status(from).CanTransitionTo(Status(to))
+}
+
+// StatusTransitions returns the statuses an applicant may move to from status
+func StatusTransitions(status string) []string {
+	transitions := []string{}
+	for _, to := range statusTransitions[Status(status)] {
+		transitions = append(transitions, string(to))
+	}
+	return transitions
+}
+
+// IsTerminalStatus checks if a status has no outgoing transitions
+func IsTerminalStatus(status string) bool {
+	return Status(status).IsTerminal()
+}
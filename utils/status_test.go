@@ -0,0 +1,40 @@
+package utils
+
+import "testing"
+
+func TestValidateTransition(t *testing.T) {
+	// Every legal move; any other pair of statuses is illegal
+	legal := map[[2]Status]bool{
+		{StatusPending, StatusReviewed}:     true,
+		{StatusPending, StatusRejected}:     true,
+		{StatusReviewed, StatusInterviewed}: true,
+		{StatusReviewed, StatusRejected}:    true,
+		{StatusInterviewed, StatusHired}:    true,
+		{StatusInterviewed, StatusRejected}: true,
+	}
+	statuses := append(allowedStatuses, "archived", "")
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := legal[[2]Status{from, to}]
+			if got := ValidateTransition(string(from), string(to)); got != want {
+				t.Errorf("ValidateTransition(%q, %q) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestIsTerminalStatus(t *testing.T) {
+	tests := map[string]bool{
+		"pending":     false,
+		"reviewed":    false,
+		"interviewed": false,
+		"hired":       true,
+		"rejected":    true,
+		"archived":    false,
+	}
+	for status, want := range tests {
+		if got := IsTerminalStatus(status); got != want {
+			t.Errorf("IsTerminalStatus(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ValidateURL checks that value is an absolute http or https URL with a host
+// and at most max characters
+func ValidateURL(value string, max int) bool {
+	if value == "" || !ValidateMaxLength(value, max) || strings.ContainsAny(value, " \t\r\n") {
+		return false
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return false
+	}
+	return parsed.Hostname() != ""
+}
@@ -1,8 +1,12 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // ValidateEmail checks if email format is valid
@@ -11,13 +15,52 @@ func ValidateEmail(email string) bool {
 	return emailRegex.MatchString(email)
 }
 
-// ValidatePhone checks if phone number format is valid (basic validation)
+// ValidatePhone checks that a phone number can be normalized by NormalizePhone
 func ValidatePhone(phone string) bool {
 	if phone == "" {
 		return true // Phone is optional
 	}
-	phoneRegex := regexp.MustCompile(`^\+?[\d\s\-\(\)]{10,15}$`)
-	return phoneRegex.MatchString(phone)
+	_, err := NormalizePhone(phone, "")
+	return err == nil
+}
+
+// ValidateMaxLength checks that a string has at most max characters
+func ValidateMaxLength(value string, max int) bool {
+	return utf8.RuneCountInString(value) <= max
+}
+
+// Length bounds for position titles
+const (
+	MinPositionLength = 2
+	MaxPositionLength = 100
+)
+
+// ValidatePosition checks that a position title has a sensible length, only
+// printable characters and no punctuation at either end. Brackets are allowed
+// at the ends so titles like "Engineer (Go)" pass.
+func ValidatePosition(position string) error {
+	length := utf8.RuneCountInString(position)
+	if length < MinPositionLength || length > MaxPositionLength {
+		return fmt.Errorf("must be between %d and %d characters", MinPositionLength, MaxPositionLength)
+	}
+	for _, r := range position {
+		if !unicode.IsPrint(r) {
+			return errors.New("must contain only printable characters")
+		}
+	}
+
+	first, _ := utf8.DecodeRuneInString(position)
+	last, _ := utf8.DecodeLastRuneInString(position)
+	if (unicode.IsPunct(first) && !unicode.Is(unicode.Ps, first)) ||
+		(unicode.IsPunct(last) && !unicode.Is(unicode.Pe, last)) {
+		return errors.New("must not start or end with punctuation")
+	}
+	return nil
+}
+
+// ValidateCurrency checks that code looks like an ISO 4217 currency code
+func ValidateCurrency(code string) bool {
+	return regexp.MustCompile(`^[A-Z]{3}$`).MatchString(code)
 }
 
 // SanitizeString removes extra whitespace and trims string
@@ -25,13 +68,8 @@ func SanitizeString(input string) string {
 	return strings.TrimSpace(input)
 }
 
-// ValidateStatus checks if status is one of the allowed values
-func ValidateStatus(status string) bool {
-	allowedStatuses := []string{"pending", "reviewed", "interviewed", "hired", "rejected"}
-	for _, allowed := range allowedStatuses {
-		if status == allowed {
-			return true
-		}
-	}
-	return false
+// FieldError describes a validation problem with a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePosition(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		want     string
+	}{
+		{"plain", "Engineer", ""},
+		{"shortest", "QA", ""},
+		{"longest", strings.Repeat("a", MaxPositionLength), ""},
+		{"multibyte at the limit", strings.Repeat("é", MaxPositionLength), ""},
+		{"inner punctuation", "Sr. Engineer, Backend/Platform", ""},
+		{"bracketed suffix", "Engineer (Go)", ""},
+		{"bracketed prefix", "[Contract] Designer", ""},
+		{"accented", "Ingénieur logiciel", ""},
+		{"digits", "Level 3 Support", ""},
+		{"empty", "", "between"},
+		{"too short", "A", "between"},
+		{"too long", strings.Repeat("a", MaxPositionLength+1), "between"},
+		{"multibyte too long", strings.Repeat("é", MaxPositionLength+1), "between"},
+		{"newline", "Senior\nEngineer", "printable"},
+		{"tab", "Senior\tEngineer", "printable"},
+		{"nul", "Engineer\x00", "printable"},
+		{"zero width space", "Engi\u200bneer", "printable"},
+		{"leading dash", "-Engineer", "punctuation"},
+		{"trailing period", "Engineer.", "punctuation"},
+		{"trailing comma", "Engineer,", "punctuation"},
+		{"leading closing bracket", ")Engineer", "punctuation"},
+		{"trailing opening bracket", "Engineer (", "punctuation"},
+		{"only punctuation", "!!", "punctuation"},
+	}
+	for _, tc := range tests {
+		err := ValidatePosition(tc.position)
+		switch {
+		case tc.want == "" && err != nil:
+			t.Errorf("%s: ValidatePosition(%q) = %v, want nil", tc.name, tc.position, err)
+		case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+			t.Errorf("%s: ValidatePosition(%q) = %v, want an error mentioning %q", tc.name, tc.position, err, tc.want)
+		}
+	}
+}
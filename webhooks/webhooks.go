@@ -0,0 +1,127 @@
+// Package webhooks delivers applicant events to the URLs registered as
+// webhooks. Each delivery is a background job of its own, so a slow or failing
+// endpoint is retried without delaying the request or resending the event to
+// the other webhooks.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"job-tracker/database"
+	"job-tracker/events"
+	"job-tracker/jobs"
+	"job-tracker/models"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxResponseBody is how much of a response is read before the connection is
+// released; receivers only need to return a 2xx status
+const maxResponseBody = 64 << 10
+
+// Sender posts events to the registered webhooks
+type Sender struct {
+	queue  jobs.Queue
+	client *http.Client
+}
+
+// NewSender creates a sender that queues deliveries on queue and gives each
+// request timeout to complete
+func NewSender(queue jobs.Queue, timeout time.Duration) *Sender {
+	return &Sender{queue: queue, client: &http.Client{Timeout: timeout}}
+}
+
+// Handle is the events.Handler for webhooks. It queues one delivery for every
+// webhook subscribed to the event's type.
+func (s *Sender) Handle(ctx context.Context, event events.Event) error {
+	var hooks []models.Webhook
+	if err := database.DB.WithContext(ctx).Order("id ASC").Find(&hooks).Error; err != nil {
+		return fmt.Errorf("loading webhooks: %w", err)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding %s event: %w", event.Type, err)
+	}
+
+	for _, hook := range hooks {
+		if !hook.Events.Includes(event.Type) {
+			continue
+		}
+		hook := hook
+		job := jobs.Job{
+			Name: fmt.Sprintf("webhook:%d:%s:%d", hook.ID, event.Type, event.ApplicantID),
+			Run: func(ctx context.Context) error {
+				return s.deliver(ctx, hook, event.Type, body)
+			},
+		}
+		if err := s.queue.Enqueue(job); err != nil {
+			log.Printf("Failed to queue %s delivery to webhook %d: %v", event.Type, hook.ID, err)
+		}
+	}
+	return nil
+}
+
+// deliver posts one signed event to a webhook. Network errors and non-2xx
+// responses are logged and returned so the job is retried.
+func (s *Sender) deliver(ctx context.Context, hook models.Webhook, eventType string, body []byte) error {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, eventType)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(hook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Printf("Webhook %d delivery of %s to %s failed: %v", hook.ID, eventType, hook.URL, err)
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		log.Printf("Webhook %d delivery of %s to %s failed: status %d", hook.ID, eventType, hook.URL, resp.StatusCode)
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+// webhook secret. Receivers recompute it to check that a delivery is
+// authentic and, with the timestamp, recent.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewSecret generates a random signing secret for a new webhook
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
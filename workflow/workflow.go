@@ -0,0 +1,206 @@
+// Package workflow applies declarative rules when an applicant changes status,
+// such as filling in a field or appending a note on rejection. Rules run inside
+// the status change transaction, so their effects commit or roll back with it.
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"job-tracker/models"
+	"job-tracker/pii"
+	"job-tracker/utils"
+	"os"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Rule runs its actions when an applicant moves from From to To. An empty
+// From matches any previous status.
+type Rule struct {
+	From    utils.Status `json:"from,omitempty"`
+	To      utils.Status `json:"to"`
+	Actions []Action     `json:"actions"`
+}
+
+// Action is one side effect of a rule. Field and Value are interpreted by the
+// action type.
+type Action struct {
+	Type  string `json:"type"`
+	Field string `json:"field,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// ActionFunc performs an action on the locked applicant row and returns the
+// fields it changed
+type ActionFunc func(tx *gorm.DB, applicant *models.Applicant, action Action) (map[string]models.FieldChange, error)
+
+// actionType is a registered action: validate checks an action's settings at
+// load time and run performs it
+type actionType struct {
+	validate func(action Action) error
+	run      ActionFunc
+}
+
+// actions holds the available action types by name
+var actions = map[string]actionType{
+	"set_field":   {validate: validateSetField, run: setField},
+	"append_note": {validate: validateAppendNote, run: appendNote},
+}
+
+// rules is the active rule set, loaded at startup
+var rules []Rule
+
+// Register adds an action type so rules can use it. It must be called before
+// LoadRules.
+func Register(name string, validate func(action Action) error, run ActionFunc) {
+	actions[name] = actionType{validate: validate, run: run}
+}
+
+// LoadRules reads the rule set from a JSON file holding a list of rules and
+// checks every rule, so a typo stops the server at boot. An empty path leaves
+// the rule set empty.
+func LoadRules(path string) error {
+	if path == "" {
+		rules = nil
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var loaded []Rule
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	for i, rule := range loaded {
+		if rule.From != "" && !rule.From.Valid() {
+			return fmt.Errorf("rule %d: unknown from status %q", i, rule.From)
+		}
+		if !rule.To.Valid() {
+			return fmt.Errorf("rule %d: unknown to status %q", i, rule.To)
+		}
+		for j, action := range rule.Actions {
+			kind, ok := actions[action.Type]
+			if !ok {
+				return fmt.Errorf("rule %d action %d: unknown action type %q", i, j, action.Type)
+			}
+			if err := kind.validate(action); err != nil {
+				return fmt.Errorf("rule %d action %d: %w", i, j, err)
+			}
+		}
+	}
+
+	rules = loaded
+	return nil
+}
+
+// Apply runs the actions of every rule matching a move from one status to
+// another, in file order, and returns the combined field changes. applicant
+// must be locked by the caller's transaction and is updated in place.
+func Apply(tx *gorm.DB, applicant *models.Applicant, from, to utils.Status) (map[string]models.FieldChange, error) {
+	changes := make(map[string]models.FieldChange)
+	for _, rule := range rules {
+		if rule.To != to || (rule.From != "" && rule.From != from) {
+			continue
+		}
+		for _, action := range rule.Actions {
+			changed, err := actions[action.Type].run(tx, applicant, action)
+			if err != nil {
+				return nil, fmt.Errorf("%s action on applicant %d: %w", action.Type, applicant.ID, err)
+			}
+			for field, change := range changed {
+				if earlier, ok := changes[field]; ok {
+					change.Old = earlier.Old
+				}
+				changes[field] = change
+			}
+		}
+	}
+	return changes, nil
+}
+
+// settableFields maps the fields set_field may change to their columns.
+// Identity, status and timestamp fields are deliberately left out.
+var settableFields = map[string]string{
+	"position":        "position",
+	"phone":           "phone",
+	"phone_extension": "phone_extension",
+	"resume":          "resume",
+	"notes":           "notes",
+	"linkedin_url":    "linked_in_url",
+	"portfolio_url":   "portfolio_url",
+}
+
+// fieldValue returns a pointer to the applicant field set_field may change
+func fieldValue(applicant *models.Applicant, field string) *string {
+	switch field {
+	case "position":
+		return &applicant.Position
+	case "phone":
+		return (*string)(&applicant.Phone)
+	case "phone_extension":
+		return &applicant.PhoneExtension
+	case "resume":
+		return (*string)(&applicant.Resume)
+	case "notes":
+		return &applicant.Notes
+	case "linkedin_url":
+		return &applicant.LinkedInURL
+	case "portfolio_url":
+		return &applicant.PortfolioURL
+	}
+	return nil
+}
+
+func validateSetField(action Action) error {
+	if _, ok := settableFields[action.Field]; !ok {
+		return fmt.Errorf("set_field cannot change %q", action.Field)
+	}
+	return nil
+}
+
+// setField sets a field to a fixed value; an empty value clears it
+func setField(tx *gorm.DB, applicant *models.Applicant, action Action) (map[string]models.FieldChange, error) {
+	value := fieldValue(applicant, action.Field)
+	if *value == action.Value {
+		return nil, nil
+	}
+	// Taken before the write, which also sets the field on applicant
+	change := models.FieldChange{Old: *value, New: action.Value}
+	// Encrypted fields are written through their column type so they are
+	// encrypted like any other write
+	var newValue interface{} = action.Value
+	if action.Field == "phone" || action.Field == "resume" {
+		newValue = pii.EncryptedString(action.Value)
+	}
+	if err := tx.Model(applicant).Update(settableFields[action.Field], newValue).Error; err != nil {
+		return nil, err
+	}
+	*value = action.Value
+	return map[string]models.FieldChange{action.Field: change}, nil
+}
+
+func validateAppendNote(action Action) error {
+	if action.Value == "" {
+		return fmt.Errorf("append_note needs a value")
+	}
+	return nil
+}
+
+// appendNote adds a timestamped line to the notes, like the notes append endpoint
+func appendNote(tx *gorm.DB, applicant *models.Applicant, action Action) (map[string]models.FieldChange, error) {
+	line := fmt.Sprintf("[%s] %s", time.Now().UTC().Format(time.RFC3339), action.Value)
+	notes := line
+	if applicant.Notes != "" {
+		notes = applicant.Notes + "\n" + line
+	}
+	change := models.FieldChange{Old: applicant.Notes, New: notes}
+	if err := tx.Model(applicant).Update("notes", notes).Error; err != nil {
+		return nil, err
+	}
+	applicant.Notes = notes
+	return map[string]models.FieldChange{"notes": change}, nil
+}
@@ -0,0 +1,189 @@
+package workflow
+
+import (
+	"encoding/json"
+	"job-tracker/models"
+	"job-tracker/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// useRules loads rules from a temporary file for the duration of the test
+func useRules(t *testing.T, rules []Rule) {
+	t.Helper()
+	data, err := json.Marshal(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadRules(path); err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	t.Cleanup(func() { LoadRules("") })
+}
+
+// newTestApplicant stores an applicant in a fresh in-memory database
+func newTestApplicant(t *testing.T, applicant models.Applicant) (*gorm.DB, models.Applicant) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	if err := db.AutoMigrate(&models.Applicant{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&applicant).Error; err != nil {
+		t.Fatal(err)
+	}
+	return db, applicant
+}
+
+func TestLoadRulesRejectsInvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"unknown to", `[{"to": "archived", "actions": []}]`, "unknown to status"},
+		{"unknown from", `[{"from": "archived", "to": "hired", "actions": []}]`, "unknown from status"},
+		{"unknown action", `[{"to": "hired", "actions": [{"type": "remove_everything"}]}]`, "unknown action type"},
+		{"set_field on email", `[{"to": "hired", "actions": [{"type": "set_field", "field": "email", "value": "x"}]}]`, "cannot change"},
+		{"set_field on status", `[{"to": "hired", "actions": [{"type": "set_field", "field": "status", "value": "x"}]}]`, "cannot change"},
+		{"append_note without value", `[{"to": "hired", "actions": [{"type": "append_note"}]}]`, "needs a value"},
+		{"not a list", `{"to": "hired"}`, "parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tt.json), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			err := LoadRules(path)
+			if err == nil {
+				t.Fatal("LoadRules succeeded, want an error")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRulesExample(t *testing.T) {
+	if err := LoadRules("rules.example.json"); err != nil {
+		t.Fatalf("LoadRules(rules.example.json): %v", err)
+	}
+	t.Cleanup(func() { LoadRules("") })
+}
+
+func TestApplyMatchesRules(t *testing.T) {
+	useRules(t, []Rule{
+		{To: utils.StatusRejected, Actions: []Action{{Type: "set_field", Field: "portfolio_url", Value: ""}}},
+		{From: utils.StatusInterviewed, To: utils.StatusHired, Actions: []Action{{Type: "set_field", Field: "position", Value: "Onboarding"}}},
+	})
+
+	tests := []struct {
+		name          string
+		from, to      utils.Status
+		wantFields    []string
+		wantPosition  string
+		wantPortfolio string
+	}{
+		{"any from", utils.StatusPending, utils.StatusRejected, []string{"portfolio_url"}, "Engineer", ""},
+		{"any from, other status", utils.StatusInterviewed, utils.StatusRejected, []string{"portfolio_url"}, "Engineer", ""},
+		{"matching from", utils.StatusInterviewed, utils.StatusHired, []string{"position"}, "Onboarding", "https://example.com"},
+		{"other from", utils.StatusReviewed, utils.StatusHired, nil, "Engineer", "https://example.com"},
+		{"no rule for to", utils.StatusPending, utils.StatusReviewed, nil, "Engineer", "https://example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, applicant := newTestApplicant(t, models.Applicant{
+				Name: "Ada", Email: "ada@example.com", Position: "Engineer",
+				Status: tt.to, PortfolioURL: "https://example.com",
+			})
+
+			changes, err := Apply(db, &applicant, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if len(changes) != len(tt.wantFields) {
+				t.Fatalf("changes = %v, want fields %v", changes, tt.wantFields)
+			}
+			for _, field := range tt.wantFields {
+				change, ok := changes[field]
+				if !ok {
+					t.Errorf("changes = %v, missing %s", changes, field)
+				} else if change.Old == change.New {
+					t.Errorf("%s change = %+v, want the old and new values", field, change)
+				}
+			}
+
+			var stored models.Applicant
+			if err := db.First(&stored, applicant.ID).Error; err != nil {
+				t.Fatal(err)
+			}
+			if stored.Position != tt.wantPosition || stored.PortfolioURL != tt.wantPortfolio {
+				t.Errorf("stored position, portfolio = %q, %q, want %q, %q",
+					stored.Position, stored.PortfolioURL, tt.wantPosition, tt.wantPortfolio)
+			}
+			if applicant.Position != stored.Position || applicant.PortfolioURL != stored.PortfolioURL {
+				t.Error("applicant was not updated in place")
+			}
+		})
+	}
+}
+
+func TestApplyCombinesChangesToTheSameField(t *testing.T) {
+	useRules(t, []Rule{
+		{To: utils.StatusRejected, Actions: []Action{{Type: "append_note", Value: "first"}}},
+		{To: utils.StatusRejected, Actions: []Action{{Type: "append_note", Value: "second"}}},
+	})
+	db, applicant := newTestApplicant(t, models.Applicant{
+		Name: "Ada", Email: "ada@example.com", Position: "Engineer", Status: utils.StatusRejected, Notes: "original",
+	})
+
+	changes, err := Apply(db, &applicant, utils.StatusPending, utils.StatusRejected)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	notes := changes["notes"]
+	if notes.Old != "original" {
+		t.Errorf("notes old = %v, want the value before the first rule", notes.Old)
+	}
+	lines := strings.Split(applicant.Notes, "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[1], "first") || !strings.HasSuffix(lines[2], "second") {
+		t.Errorf("notes = %q, want both lines appended in rule order", applicant.Notes)
+	}
+	if notes.New != applicant.Notes {
+		t.Errorf("notes new = %v, want %q", notes.New, applicant.Notes)
+	}
+}
+
+func TestApplySetFieldUnchangedValue(t *testing.T) {
+	useRules(t, []Rule{
+		{To: utils.StatusHired, Actions: []Action{{Type: "set_field", Field: "position", Value: "Engineer"}}},
+	})
+	db, applicant := newTestApplicant(t, models.Applicant{
+		Name: "Ada", Email: "ada@example.com", Position: "Engineer", Status: utils.StatusHired,
+	})
+
+	changes, err := Apply(db, &applicant, utils.StatusInterviewed, utils.StatusHired)
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("changes = %v, want none for a field already at the value", changes)
+	}
+}